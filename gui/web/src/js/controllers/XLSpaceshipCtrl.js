@@ -7,6 +7,12 @@ angular.module('xlspaceship')
         $scope.newOpponent = {
             host: "localhost",
             port: "8090",
+            placement: "",
+        };
+
+        $scope.newPractice = {
+            strategy: "probability-density",
+            placement: "",
         };
 
         /**
@@ -39,7 +45,30 @@ angular.module('xlspaceship')
                 spaceship_protocol: {
                     hostname: $scope.newOpponent.host,
                     port: parseInt($scope.newOpponent.port, 10),
-                }
+                },
+                placement: $scope.newOpponent.placement || undefined,
+            }, {headers: {'Content-Type': 'application/json'}})
+                .then(function(res) {
+                    console.log(res.data);
+
+                    $scope.games[res.data.game_id] = res.data;
+
+                    $state.go('app.xlspaceship.play', {gameID: res.data.game_id});
+                }, function(err) {
+                    console.log(err);
+                    alert(err.data || err);
+
+                    throw err
+                });
+        }
+
+        /**
+         * practice against an AI opponent that runs in our own process
+         */
+        function practice() {
+            $http.post("/xl-spaceship/user/game/new", {
+                practice: $scope.newPractice.strategy,
+                placement: $scope.newPractice.placement || undefined,
             }, {headers: {'Content-Type': 'application/json'}})
                 .then(function(res) {
                     console.log(res.data);
@@ -74,6 +103,7 @@ angular.module('xlspaceship')
         }
 
         $scope.challange = challange;
+        $scope.practice = practice;
         $scope.refreshGame = refreshGame;
 
         // fetch self data straight away
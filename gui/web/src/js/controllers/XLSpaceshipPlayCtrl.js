@@ -7,8 +7,8 @@ angular.module('xlspaceship')
          * generate a random shot
          */
         function randomShot() {
-            let x = randomIntFromInterval(0, 15);
-            let y = randomIntFromInterval(0, 15);
+            let x = randomIntFromInterval(0, $scope.game.rules.width - 1);
+            let y = randomIntFromInterval(0, $scope.game.rules.height - 1);
 
             return x.toString(16) + "x" + y.toString(16);
         }
@@ -25,6 +25,22 @@ angular.module('xlspaceship')
             return salvo;
         }
 
+        /**
+         * let the backend suggest a salvo, it never includes cells we already fired at
+         *  falls back to a random salvo when there's no hint (eg. when the spaceships aren't placed yet)
+         */
+        function hint() {
+            return $http.get("/xl-spaceship/user/game/" + $stateParams.gameID + "/hint", {
+                params: {difficulty: $scope.hintDifficulty},
+            }).then(function(res) {
+                $scope.salvo = res.data.salvo;
+            }, function(err) {
+                console.log(err);
+
+                $scope.salvo = randomSalvo($scope.game.self.shots);
+            });
+        }
+
         /**
          * refresh the game status
          */
@@ -56,14 +72,32 @@ angular.module('xlspaceship')
                 console.log(res.data);
 
                 return refresh().then(function() {
-                    // new random salvo for next round
-                    $scope.salvo = randomSalvo($scope.game.self.shots);
+                    // new suggested salvo for next round
+                    return hint();
                 });
             });
         }
 
+        /**
+         * let the backend place our spaceships randomly
+         */
+        function placeRandom() {
+            $http.put("/xl-spaceship/user/game/" + $stateParams.gameID + "/board", {
+                random: true,
+            }, {headers: {'Content-Type': 'application/json'}}).catch(function(err) {
+                console.log(err);
+                alert(err.data || err);
+            }).then(function() {
+                return refresh();
+            });
+        }
+
+        $scope.hintDifficulty = "easy";
+
         $scope.refresh = refresh;
+        $scope.hint = hint;
         $scope.fireSalvo = fireSalvo;
+        $scope.placeRandom = placeRandom;
 
         // if we're missing the game data then attempt to refresh it, if it fails we goto welcome screen
         if (!$scope.game) {
@@ -71,15 +105,15 @@ angular.module('xlspaceship')
                 .then(function(game) {
                     console.log('refreshed');
 
-                    // assign a random salvo to our input state
-                    $scope.salvo = randomSalvo($scope.game.self.shots);
+                    // assign a suggested salvo to our input state
+                    return hint();
                 }, function() {
                     $state.go('app.xlspaceship.welcome');
                 })
             ;
         } else {
-            // assign a random salvo to our input state
-            $scope.salvo = randomSalvo($scope.game.self.shots);
+            // assign a suggested salvo to our input state
+            hint();
         }
 
         // setup interval to fetch fresh data
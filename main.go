@@ -10,12 +10,17 @@ import (
 
 	"strings"
 
+	"io/ioutil"
+
 	"sync"
+	"text/tabwriter"
 	"time"
 
 	"github.com/manifoldco/promptui"
 	"github.com/pkg/browser"
+	"github.com/rubensayshi/xlspaceship/pkg/ssai"
 	"github.com/rubensayshi/xlspaceship/pkg/ssclient"
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
 )
 
 // helper to get int from `env` var or use default
@@ -73,6 +78,21 @@ var fPort = flag.Int("port", maybeGetEnvInt("PORT", 8080), "port to serve the RE
 var fPlayerID = flag.String("playerID", maybeGetEnv("PLAYERID", ""), "your player ID")
 var fPlayerName = flag.String("playerName", maybeGetEnv("PLAYERNAME", ""), "your player name")
 var fCheat = flag.Bool("cheat", maybeGetEnvBool("CHEAT", false), "enable cheat mode")
+var fWidth = flag.Int("width", maybeGetEnvInt("WIDTH", ssgame.DefaultWidth), "width of the board for games you start")
+var fHeight = flag.Int("height", maybeGetEnvInt("HEIGHT", ssgame.DefaultHeight), "height of the board for games you start")
+var fFleetFile = flag.String("fleetfile", maybeGetEnv("FLEETFILE", ""), "JSON file with named fleets to use for games you start")
+var fFleet = flag.String("fleet", maybeGetEnv("FLEET", ""), "name of the fleet from the fleetfile to use for games you start")
+var fSalvo = flag.String("salvo", maybeGetEnv("SALVO", ssgame.SalvoRuleShipsAliveName), "salvo rule for games you start (single-shot, ships-alive, cells-alive or fixed)")
+var fSalvoShots = flag.Int("salvoshots", maybeGetEnvInt("SALVOSHOTS", 0), "number of shots per salvo for the fixed salvo rule")
+var fChainOnHit = flag.Bool("chainonhit", maybeGetEnvBool("CHAINONHIT", false), "keep the turn after a salvo that hits for games you start")
+var fMirror = flag.Bool("mirror", maybeGetEnvBool("MIRROR", false), "allow spaceships to be placed as their mirror image for games you start")
+var fSeed = flag.Int("seed", maybeGetEnvInt("SEED", 0), "seed for all randomness, for debugging, by default every game is seeded from crypto/rand")
+var fDataDir = flag.String("datadir", maybeGetEnv("DATADIR", ""), "directory to store games in so they survive a restart, by default games are only kept in memory")
+var fAutopilot = flag.String("autopilot", maybeGetEnv("AUTOPILOT", ""), "strategy to fire salvos with automatically on your turn for every game (random, hunt-target or probability-density), by default you fire yourself")
+var fPractice = flag.String("practice", maybeGetEnv("PRACTICE", ""), "start a practice game against an AI opponent with this strategy (random, hunt-target or probability-density), no other player needed")
+var fStrategies = flag.String("strategies", maybeGetEnv("STRATEGIES", ssai.HuntTargetStrategyName+","+ssai.ProbabilityDensityStrategyName), "comma separated strategies to play against each other with the tournament command")
+var fGames = flag.Int("games", maybeGetEnvInt("GAMES", 1000), "number of games for every 2 strategies with the tournament command")
+var fPlacement = flag.String("placement", maybeGetEnv("PLACEMENT", ""), "strategy to place your spaceships with (random, edge, cluster, spread or anti-density), by default every position is as likely")
 var fDontOpenGui = flag.Bool("dontopengui", maybeGetEnvBool("DONTOPENGUI", false), "don't pop open the GUI when the process starts")
 
 func maybePromptPlayerID() {
@@ -105,7 +125,122 @@ func maybePromptPlayerName() {
 	}
 }
 
+// load the fleet from the fleetfile if one is provided, otherwise use the fleet for a standard game
+func loadFleet() (ssgame.Fleet, error) {
+	if *fFleetFile == "" {
+		if *fFleet != "" {
+			return nil, fmt.Errorf("can't use fleet [%s] without a fleetfile", *fFleet)
+		}
+
+		return ssgame.BaseGameFleet(), nil
+	}
+
+	data, err := ioutil.ReadFile(*fFleetFile)
+	if err != nil {
+		return nil, err
+	}
+
+	fleets, err := ssgame.FleetsFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the fleet name can be omitted when there's only 1 fleet in the file
+	if *fFleet == "" && len(fleets) == 1 {
+		for _, fleet := range fleets {
+			return fleet, nil
+		}
+	}
+
+	fleet, ok := fleets[*fFleet]
+	if !ok {
+		return nil, fmt.Errorf("fleet [%s] not found in %s", *fFleet, *fFleetFile)
+	}
+
+	return fleet, nil
+}
+
+// the rules for games we start from the flags
+func loadRules() (*ssgame.Rules, error) {
+	fleet, err := loadFleet()
+	if err != nil {
+		return nil, err
+	}
+
+	salvo, err := ssgame.SalvoRuleFromName(*fSalvo, *fSalvoShots)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssgame.Rules{
+		Width:      *fWidth,
+		Height:     *fHeight,
+		Fleet:      fleet,
+		Salvo:      salvo,
+		ChainOnHit: *fChainOnHit,
+		Mirror:     *fMirror,
+	}, nil
+}
+
+// play the strategies against each other with the rules from the flags and print how they did
+func tournament() {
+	rules, err := loadRules()
+	if err != nil {
+		panic(err)
+	}
+
+	strategies := make([]ssai.Strategy, 0)
+	for _, name := range strings.Split(*fStrategies, ",") {
+		strategy, err := ssai.StrategyFromName(strings.TrimSpace(name))
+		if err != nil {
+			panic(err)
+		}
+
+		strategies = append(strategies, strategy)
+	}
+
+	// print the seed so a tournament can be played again
+	seed := int64(*fSeed)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	fmt.Printf("Playing %d games for every 2 strategies (seed: %d) ... \n", *fGames, seed)
+
+	res, err := ssclient.RunTournament(rules, strategies, *fGames, seed)
+	if err != nil {
+		panic(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "\nstrategy\tgames\twins\twin rate (95%% CI)\tavg salvos to win (95%% CI)\n")
+	for _, strategy := range res.Strategies {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.3f (%.3f - %.3f)\t%.2f (± %.2f)\n",
+			strategy.Name, strategy.Games, strategy.Wins,
+			strategy.WinRate, strategy.WinRateLow, strategy.WinRateHigh,
+			strategy.AvgSalvos, strategy.AvgSalvosErr)
+	}
+
+	fmt.Fprintf(w, "\nmatchup\tgames\twins\twin rate (95%% CI)\tavg turns (95%% CI)\n")
+	for _, matchup := range res.Matchups {
+		fmt.Fprintf(w, "%s vs %s\t%d\t%d - %d\t%.3f (%.3f - %.3f)\t%.2f (± %.2f)\n",
+			matchup.Strategies[0], matchup.Strategies[1], matchup.Games, matchup.Wins[0], matchup.Wins[1],
+			matchup.WinRate, matchup.WinRateLow, matchup.WinRateHigh,
+			matchup.AvgTurns, matchup.AvgTurnsErr)
+	}
+
+	w.Flush()
+}
+
 func main() {
+	// `xlspaceship tournament [flags]` plays AI strategies against each other instead of starting a player
+	if len(os.Args) > 1 && os.Args[1] == "tournament" {
+		flag.CommandLine.Parse(os.Args[2:])
+		tournament()
+		return
+	}
+
 	fmt.Printf("XLSpaceship starting ... \n")
 	flag.Parse()
 
@@ -114,11 +249,56 @@ func main() {
 	maybePromptPlayerName()
 
 	// init the main controller of the game
-	s := ssclient.NewXLSpaceship(*fPlayerID, *fPlayerName, "localhost", *fPort)
+	//  when there's a datadir the games are stored there and the games from a previous run are reloaded
+	var s *ssclient.XLSpaceship
+	if *fDataDir != "" {
+		store, err := ssclient.NewFileGameStore(*fDataDir)
+		if err != nil {
+			panic(err)
+		}
+
+		s, err = ssclient.NewXLSpaceshipWithStore(*fPlayerID, *fPlayerName, "localhost", *fPort, store)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		s = ssclient.NewXLSpaceship(*fPlayerID, *fPlayerName, "localhost", *fPort)
+	}
 	// enable cheat mode if configured
 	if *fCheat {
 		s.EnableCheatMode()
 	}
+	// let the autopilot play all our games if configured
+	if *fAutopilot != "" {
+		strategy, err := ssai.StrategyFromName(*fAutopilot)
+		if err != nil {
+			panic(err)
+		}
+
+		s.SetAutopilot(strategy)
+	}
+	// place our spaceships with a strategy if configured
+	if *fPlacement != "" {
+		placement, err := ssgame.PlacementStrategyFromName(*fPlacement)
+		if err != nil {
+			panic(err)
+		}
+
+		s.SetPlacementStrategy(placement)
+	}
+	// make all randomness deterministic if configured
+	if *fSeed != 0 {
+		s.SetRandomSeed(int64(*fSeed))
+	}
+	// set the rules for games we start
+	rules, err := loadRules()
+	if err != nil {
+		panic(err)
+	}
+	err = s.SetDefaultRules(rules)
+	if err != nil {
+		panic(err)
+	}
 
 	// create wg that will control when we exit
 	wg := &sync.WaitGroup{}
@@ -130,6 +310,16 @@ func main() {
 	// serve the rest API
 	ssclient.Serve(s, *fPort, wg)
 
+	// start a practice game if configured
+	if *fPractice != "" {
+		gameID, err := s.InitPracticeGame(*fPractice)
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Printf("Started practice game %s \n", gameID)
+	}
+
 	// open or print the gui URL
 	guiUrl := fmt.Sprintf("http://localhost:%d/gui/game.html", *fPort)
 	if !*fDontOpenGui {
@@ -0,0 +1,58 @@
+package ssai
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+)
+
+// the 4 coords next to the coords, or all 8 coords surrounding it with diagonal, can be out of bounds
+func neighbours(coords *ssgame.Coords, diagonal bool) ssgame.CoordsGroup {
+	res := ssgame.CoordsGroup{
+		ssgame.NewCoords(coords.X(), coords.Y()-1),
+		ssgame.NewCoords(coords.X()-1, coords.Y()),
+		ssgame.NewCoords(coords.X()+1, coords.Y()),
+		ssgame.NewCoords(coords.X(), coords.Y()+1),
+	}
+
+	if diagonal {
+		res = append(res, diagonals(coords)...)
+	}
+
+	return res
+}
+
+// the 4 coords diagonal to the coords, can be out of bounds
+func diagonals(coords *ssgame.Coords) ssgame.CoordsGroup {
+	return ssgame.CoordsGroup{
+		ssgame.NewCoords(coords.X()-1, coords.Y()-1),
+		ssgame.NewCoords(coords.X()+1, coords.Y()-1),
+		ssgame.NewCoords(coords.X()-1, coords.Y()+1),
+		ssgame.NewCoords(coords.X()+1, coords.Y()+1),
+	}
+}
+
+// sort coords row by row
+func sortCoords(coords ssgame.CoordsGroup) {
+	sort.Slice(coords, func(i, j int) bool {
+		if coords[i].Y() != coords[j].Y() {
+			return coords[i].Y() < coords[j].Y()
+		}
+
+		return coords[i].X() < coords[j].X()
+	})
+}
+
+func sortStable(coords ssgame.CoordsGroup, less func(a, b *ssgame.Coords) bool) {
+	sort.SliceStable(coords, func(i, j int) bool {
+		return less(coords[i], coords[j])
+	})
+}
+
+func shuffle(coords ssgame.CoordsGroup, rng *rand.Rand) {
+	for i := len(coords) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		coords[i], coords[j] = coords[j], coords[i]
+	}
+}
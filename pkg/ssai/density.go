@@ -0,0 +1,177 @@
+package ssai
+
+import (
+	"math/rand"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+)
+
+const ProbabilityDensityStrategyName = "probability-density"
+
+// every placement that covers a hit we haven't killed yet is this much more likely than a placement that doesn't
+const densityHitWeight = 20.0
+
+// count every way the spaceships that are still alive can be placed on what we know of our opponent's board
+//  and fire at the cells that are covered by the most placements, this takes the shapes of the spaceships into account
+//  so it's a lot better than hunting in a pattern when the spaceships aren't lines
+type ProbabilityDensityStrategy struct{}
+
+func (s *ProbabilityDensityStrategy) Name() string {
+	return ProbabilityDensityStrategyName
+}
+
+func (s *ProbabilityDensityStrategy) Salvo(game *ssgame.Game) ssgame.CoordsGroup {
+	return ProbabilityDensitySalvo(game.OpponentBoard, game.SelfShots(), game.Rand())
+}
+
+// pick the coords for a salvo of (max) shots on the opponent board
+//  the top cells of the heatmap are picked, cells with the same probability are picked at random
+//  when there are less blank cells left than shots we only get the blank cells
+func ProbabilityDensitySalvo(board *ssgame.OpponentBoard, shots int, rng *rand.Rand) ssgame.CoordsGroup {
+	heatmap := Heatmap(board)
+
+	candidates := huntCandidates(board, rng)
+	sortStable(candidates, func(a, b *ssgame.Coords) bool {
+		return heatmap[a.Y()][a.X()] > heatmap[b.Y()][b.X()]
+	})
+
+	if len(candidates) > shots {
+		candidates = candidates[:shots]
+	}
+
+	return candidates
+}
+
+// the (relative) probability of a spaceship being on every cell of the board, by row and then column
+//  only blank cells can have a probability, cells we already fired at are 0
+func Heatmap(board *ssgame.OpponentBoard) [][]float64 {
+	rules := board.Rules()
+	openHits := OpenHits(board)
+
+	// cells no spaceship that's alive can be on, the misses and the cells of the spaceships we killed
+	//  and when spaceships can't touch also the cells around the spaceships we killed
+	blocked := make(map[ssgame.Coords]bool)
+	for y := 0; y < rules.Height; y++ {
+		for x := 0; x < rules.Width; x++ {
+			coords := ssgame.NewCoords(x, y)
+			switch board.CoordsState(coords) {
+			case ssgame.CoordsMiss:
+				blocked[*coords] = true
+
+			case ssgame.CoordsSunk:
+				blocked[*coords] = true
+
+				if rules.NoTouch {
+					for _, neighbour := range neighbours(coords, true) {
+						blocked[*neighbour] = true
+					}
+				}
+			}
+		}
+	}
+
+	heatmap := make([][]float64, rules.Height)
+	for y := range heatmap {
+		heatmap[y] = make([]float64, rules.Width)
+	}
+
+	for _, remaining := range remainingSpaceships(rules, board.Killed()) {
+		for _, shape := range remaining.shapes {
+			for offsetY := 0; offsetY < rules.Height; offsetY++ {
+				for offsetX := 0; offsetX < rules.Width; offsetX++ {
+					weight := placementWeight(rules, shape, offsetX, offsetY, blocked, openHits)
+					if weight == 0 {
+						continue
+					}
+
+					for _, coords := range shape {
+						x, y := coords.X()+offsetX, coords.Y()+offsetY
+						if board.CoordsState(ssgame.NewCoords(x, y)) == ssgame.CoordsBlank {
+							heatmap[y][x] += weight * float64(remaining.count)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return heatmap
+}
+
+// how likely the shape with the offset is, 0 when it's not possible
+func placementWeight(rules *ssgame.Rules, shape ssgame.CoordsGroup, offsetX int, offsetY int, blocked map[ssgame.Coords]bool, openHits map[ssgame.Coords]bool) float64 {
+	weight := 1.0
+	for _, coords := range shape {
+		placed := ssgame.NewCoords(coords.X()+offsetX, coords.Y()+offsetY)
+		if !rules.InBounds(placed) || blocked[*placed] {
+			return 0
+		}
+
+		if openHits[*placed] {
+			weight *= densityHitWeight
+		}
+	}
+
+	return weight
+}
+
+// a spaceship of the fleet that's still alive, in all it's orientations
+type remainingSpaceship struct {
+	count  int
+	shapes []ssgame.CoordsGroup
+}
+
+// the spaceships of the fleet that are still alive, as far as we know the names of the spaceships we killed
+//  a spaceship we killed without knowing which one it was is still counted as alive
+func remainingSpaceships(rules *ssgame.Rules, killed []string) []*remainingSpaceship {
+	counts := make(map[string]int, len(killed))
+	for _, name := range killed {
+		counts[name]++
+	}
+
+	remaining := make([]*remainingSpaceship, 0, len(rules.Fleet))
+	for _, fleetSpaceship := range rules.Fleet {
+		count := fleetSpaceship.Count - counts[fleetSpaceship.Name]
+		if count <= 0 {
+			continue
+		}
+
+		spaceship, err := ssgame.SpaceshipFromPattern(fleetSpaceship.Pattern)
+		if err != nil {
+			continue
+		}
+
+		shapes := make([]ssgame.CoordsGroup, 0, 8)
+		for _, orientation := range spaceship.Orientations(rules.Mirror) {
+			shapes = append(shapes, normalizeShape(orientation.Coords()))
+		}
+
+		remaining = append(remaining, &remainingSpaceship{
+			count:  count,
+			shapes: shapes,
+		})
+	}
+
+	return remaining
+}
+
+// move the shape so it starts at 0x0 and sort it, so the same shapes are equal
+func normalizeShape(shape ssgame.CoordsGroup) ssgame.CoordsGroup {
+	minX, minY := shape[0].X(), shape[0].Y()
+	for _, coords := range shape {
+		if coords.X() < minX {
+			minX = coords.X()
+		}
+		if coords.Y() < minY {
+			minY = coords.Y()
+		}
+	}
+
+	normalized := make(ssgame.CoordsGroup, len(shape))
+	for i, coords := range shape {
+		normalized[i] = ssgame.NewCoords(coords.X()-minX, coords.Y()-minY)
+	}
+	sortCoords(normalized)
+
+	return normalized
+}
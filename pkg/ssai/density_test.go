@@ -0,0 +1,133 @@
+package ssai
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeatmap(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	board, err := ssgame.NewBlankOpponentBoard(rules, uint8(rules.Fleet.Size()))
+	assert.NoError(err)
+
+	// spaceships fit in the middle in more ways than in a corner
+	heatmap := Heatmap(board)
+	assert.True(heatmap[8][8] > heatmap[0][0])
+	assert.True(heatmap[0][0] > 0)
+
+	// no probability for cells we already fired at
+	empty := Heatmap(board)
+	board.ApplyShotStatus(mustCoordsFromString("8x8"), ssgame.ShotStatusMiss)
+	board.ApplyShotStatus(mustCoordsFromString("3x3"), ssgame.ShotStatusHit)
+
+	heatmap = Heatmap(board)
+	assert.Equal(0.0, heatmap[8][8])
+	assert.Equal(0.0, heatmap[3][3])
+
+	// the most likely cell is one that a spaceship covering the hit would be on
+	salvo := ProbabilityDensitySalvo(board, 1, testRand())
+	assert.Equal(1, len(salvo))
+	assert.True(abs(salvo[0].X()-3) < 5 && abs(salvo[0].Y()-3) < 5, "fired at %s", salvo[0])
+	assert.True(heatmap[salvo[0].Y()][salvo[0].X()] > empty[salvo[0].Y()][salvo[0].X()])
+}
+
+func TestRemainingSpaceships(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+
+	remaining := remainingSpaceships(rules, []string{"Angle"})
+	assert.Equal(4, len(remaining))
+	for _, spaceship := range remaining {
+		assert.NotEqual(6, len(spaceship.shapes[0]))
+	}
+
+	// without kills the whole fleet is alive
+	assert.Equal(5, len(remainingSpaceships(rules, nil)))
+}
+
+func TestHeatmapSunk(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	rules.NoTouch = true
+	board, err := ssgame.NewBlankOpponentBoard(rules, uint8(rules.Fleet.Size()))
+	assert.NoError(err)
+
+	// the hits and the kill make an Angle, once it's sunk no spaceship can be on or around it
+	for _, coords := range []string{"5x5", "5x6", "5x7", "5x8", "6x8"} {
+		board.ApplyShotStatus(mustCoordsFromString(coords), ssgame.ShotStatusHit)
+	}
+	board.ApplyShotStatus(mustCoordsFromString("7x8"), ssgame.ShotStatusKill)
+	assert.Equal([]string{"Angle"}, board.Killed())
+
+	heatmap := Heatmap(board)
+	for _, coords := range []string{"4x4", "5x4", "6x5", "4x8", "8x9", "6x7"} {
+		coords := mustCoordsFromString(coords)
+		assert.Equal(0.0, heatmap[coords.Y()][coords.X()], "%s", coords)
+	}
+	assert.True(heatmap[0][0] > 0)
+}
+
+func TestProbabilityDensitySalvoFullGame(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	rng := testRand()
+
+	huntTargetShots := 0
+	densityShots := 0
+	for i := 0; i < 10; i++ {
+		selfBoard, err := ssgame.NewRandomSelfBoard(rules, rules.Fleet.Patterns(), nil, rng)
+		assert.NoError(err)
+
+		huntTargetShots += playAgainst(t, selfBoard.ToPattern(), HuntTargetSalvo, rng)
+		densityShots += playAgainst(t, selfBoard.ToPattern(), ProbabilityDensitySalvo, rng)
+	}
+
+	// on the same boards we should need less shots than hunting and targeting
+	assert.True(densityShots < huntTargetShots, "density took %d shots, hunt/target took %d", densityShots, huntTargetShots)
+}
+
+// play a game against a board with a strategy and return how many shots it took to kill every spaceship
+func playAgainst(t *testing.T, pattern []string, strategy func(*ssgame.OpponentBoard, int, *rand.Rand) ssgame.CoordsGroup, rng *rand.Rand) int {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	selfBoard, err := ssgame.NewSelfBoardFromPattern(rules, pattern)
+	assert.NoError(err)
+	opponentBoard, err := ssgame.NewBlankOpponentBoard(rules, uint8(rules.Fleet.Size()))
+	assert.NoError(err)
+
+	shots := 0
+	for !selfBoard.AllShipsDead() {
+		allowed := rules.Salvo.Shots(opponentBoard)
+
+		salvo := strategy(opponentBoard, allowed, rng)
+		assert.True(len(salvo) > 0)
+		assert.True(len(salvo) <= allowed)
+
+		salvoRes := selfBoard.ReceiveSalvo(salvo)
+		for _, shotRes := range salvoRes {
+			assert.Equal(ssgame.CoordsBlank, opponentBoard.CoordsState(shotRes.Coords))
+			opponentBoard.ApplyShotStatus(shotRes.Coords, shotRes.ShotStatus)
+		}
+
+		shots += len(salvo)
+	}
+
+	return shots
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
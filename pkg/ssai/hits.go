@@ -0,0 +1,23 @@
+package ssai
+
+import (
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+)
+
+// the coords of the hits that aren't part of a spaceship we killed yet, the board sinks the cells of the spaceships we killed
+//  (when the board can't tell all the cells of a killed spaceship the hits it's not sure of stay open)
+func OpenHits(board *ssgame.OpponentBoard) map[ssgame.Coords]bool {
+	rules := board.Rules()
+
+	openHits := make(map[ssgame.Coords]bool)
+	for y := 0; y < rules.Height; y++ {
+		for x := 0; x < rules.Width; x++ {
+			coords := ssgame.NewCoords(x, y)
+			if board.CoordsState(coords) == ssgame.CoordsHit {
+				openHits[*coords] = true
+			}
+		}
+	}
+
+	return openHits
+}
@@ -0,0 +1,113 @@
+package ssai
+
+import (
+	"math/rand"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+)
+
+const HuntTargetStrategyName = "hunt-target"
+
+// the classic strategy, hunt for spaceships in a parity pattern and once we hit something
+//  target the cells around the hit until the spaceship is killed
+type HuntTargetStrategy struct{}
+
+func (s *HuntTargetStrategy) Name() string {
+	return HuntTargetStrategyName
+}
+
+func (s *HuntTargetStrategy) Salvo(game *ssgame.Game) ssgame.CoordsGroup {
+	return HuntTargetSalvo(game.OpponentBoard, game.SelfShots(), game.Rand())
+}
+
+// pick the coords for a salvo of (max) shots on the opponent board
+//  when there are less blank cells left than shots we only get the blank cells
+func HuntTargetSalvo(board *ssgame.OpponentBoard, shots int, rng *rand.Rand) ssgame.CoordsGroup {
+	openHits := OpenHits(board)
+
+	salvo := make(ssgame.CoordsGroup, 0, shots)
+	picked := make(map[ssgame.Coords]bool, shots)
+	pick := func(candidates ssgame.CoordsGroup) {
+		for _, coords := range candidates {
+			if len(salvo) >= shots {
+				return
+			}
+			if picked[*coords] {
+				continue
+			}
+
+			picked[*coords] = true
+			salvo = append(salvo, coords)
+		}
+	}
+
+	pick(targetCandidates(board, openHits, rng))
+	pick(huntCandidates(board, rng))
+
+	return salvo
+}
+
+// the blank cells around our open hits, best first
+//  a cell next to a hit is more likely a spaceship than a cell diagonal to a hit, and more hits around it is even better
+func targetCandidates(board *ssgame.OpponentBoard, openHits map[ssgame.Coords]bool, rng *rand.Rand) ssgame.CoordsGroup {
+	rules := board.Rules()
+
+	scores := make(map[ssgame.Coords]int)
+	for hit := range openHits {
+		hit := hit
+		for _, coords := range neighbours(&hit, false) {
+			if rules.InBounds(coords) && board.CoordsState(coords) == ssgame.CoordsBlank {
+				scores[*coords] += 2
+			}
+		}
+		for _, coords := range diagonals(&hit) {
+			if rules.InBounds(coords) && board.CoordsState(coords) == ssgame.CoordsBlank {
+				scores[*coords] += 1
+			}
+		}
+	}
+
+	candidates := make(ssgame.CoordsGroup, 0, len(scores))
+	for coords := range scores {
+		coords := coords
+		candidates = append(candidates, &coords)
+	}
+
+	// shuffle before sorting so equal scores are picked at random, sort the map's random order first to keep it deterministic
+	sortCoords(candidates)
+	shuffle(candidates, rng)
+	sortStable(candidates, func(a, b *ssgame.Coords) bool {
+		return scores[*a] > scores[*b]
+	})
+
+	return candidates
+}
+
+// the blank cells in random order, first the cells of the parity pattern (like the black squares of a chess board)
+//  every spaceship that's bigger than a single cell in a line has at least 1 cell on the pattern, so we find them with half the shots
+//  after that the rest of the cells because not every spaceship is a line
+func huntCandidates(board *ssgame.OpponentBoard, rng *rand.Rand) ssgame.CoordsGroup {
+	rules := board.Rules()
+
+	parity := make(ssgame.CoordsGroup, 0, rules.Width*rules.Height/2+1)
+	rest := make(ssgame.CoordsGroup, 0, rules.Width*rules.Height/2+1)
+	for y := 0; y < rules.Height; y++ {
+		for x := 0; x < rules.Width; x++ {
+			coords := ssgame.NewCoords(x, y)
+			if board.CoordsState(coords) != ssgame.CoordsBlank {
+				continue
+			}
+
+			if (x+y)%2 == 0 {
+				parity = append(parity, coords)
+			} else {
+				rest = append(rest, coords)
+			}
+		}
+	}
+
+	shuffle(parity, rng)
+	shuffle(rest, rng)
+
+	return append(parity, rest...)
+}
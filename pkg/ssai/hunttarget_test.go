@@ -0,0 +1,144 @@
+package ssai
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+func mustCoordsFromString(coordsStr string) *ssgame.Coords {
+	coords, err := ssgame.CoordsFromString(coordsStr)
+	if err != nil {
+		panic(err)
+	}
+
+	return coords
+}
+
+func testRand() *rand.Rand {
+	return rand.New(ssgame.NewSeededRandomSource(1))
+}
+
+func TestHuntTargetSalvoHunt(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	board, err := ssgame.NewBlankOpponentBoard(rules, uint8(rules.Fleet.Size()))
+	assert.NoError(err)
+
+	salvo := HuntTargetSalvo(board, 5, testRand())
+	assert.Equal(5, len(salvo))
+
+	// nothing hit yet so we only fire on the parity pattern, and never twice on the same coords
+	seen := make(map[ssgame.Coords]bool)
+	for _, coords := range salvo {
+		assert.Equal(0, (coords.X()+coords.Y())%2)
+		assert.False(seen[*coords])
+		seen[*coords] = true
+	}
+}
+
+func TestHuntTargetSalvoTarget(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	board, err := ssgame.NewBlankOpponentBoard(rules, uint8(rules.Fleet.Size()))
+	assert.NoError(err)
+
+	board.ApplyShotStatus(mustCoordsFromString("5x5"), ssgame.ShotStatusHit)
+	board.ApplyShotStatus(mustCoordsFromString("0x0"), ssgame.ShotStatusMiss)
+
+	// the cells next to the hit first, then the diagonals
+	salvo := HuntTargetSalvo(board, 5, testRand())
+	assert.Equal(5, len(salvo))
+	assert.ElementsMatch([]string{"5x4", "4x5", "6x5", "5x6"}, []string{salvo[0].String(), salvo[1].String(), salvo[2].String(), salvo[3].String()})
+	assert.Contains([]string{"4x4", "6x4", "4x6", "6x6"}, salvo[4].String())
+
+	// a second hit next to it makes the cells in line with both hits the best
+	board.ApplyShotStatus(mustCoordsFromString("6x5"), ssgame.ShotStatusHit)
+
+	salvo = HuntTargetSalvo(board, 1, testRand())
+	assert.Equal(1, len(salvo))
+	assert.Contains([]string{"5x4", "6x4", "5x6", "6x6"}, salvo[0].String())
+
+	// once it's killed we go back to hunting, it's an Angle so the board knows all of it's cells
+	for _, coords := range []string{"5x2", "5x3", "5x4"} {
+		board.ApplyShotStatus(mustCoordsFromString(coords), ssgame.ShotStatusHit)
+	}
+	board.ApplyShotStatus(mustCoordsFromString("7x5"), ssgame.ShotStatusKill)
+	assert.Equal(0, len(OpenHits(board)))
+
+	salvo = HuntTargetSalvo(board, 4, testRand())
+	assert.Equal(4, len(salvo))
+	for _, coords := range salvo {
+		assert.Equal(0, (coords.X()+coords.Y())%2)
+	}
+}
+
+func TestOpenHits(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	board, err := ssgame.NewBlankOpponentBoard(rules, uint8(rules.Fleet.Size()))
+	assert.NoError(err)
+
+	// a kill only clears the hits of the spaceship the board sank, the hits and the kill make an Angle
+	for _, coords := range []string{"0x0", "0x1", "0x2", "0x3", "1x3", "8x8"} {
+		board.ApplyShotStatus(mustCoordsFromString(coords), ssgame.ShotStatusHit)
+	}
+	board.ApplyShotStatus(mustCoordsFromString("2x3"), ssgame.ShotStatusKill)
+
+	assert.Equal(map[ssgame.Coords]bool{*mustCoordsFromString("8x8"): true}, OpenHits(board))
+	assert.Equal(ssgame.CoordsSunk, board.CoordsState(mustCoordsFromString("0x0")))
+}
+
+func TestHuntTargetSalvoFullGame(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	rng := testRand()
+
+	for i := 0; i < 10; i++ {
+		selfBoard, err := ssgame.NewRandomSelfBoard(rules, rules.Fleet.Patterns(), nil, rng)
+		assert.NoError(err)
+		opponentBoard, err := ssgame.NewBlankOpponentBoard(rules, uint8(rules.Fleet.Size()))
+		assert.NoError(err)
+
+		shots := 0
+		for !selfBoard.AllShipsDead() {
+			// the salvo rule of a standard game, 1 shot for every spaceship alive
+			allowed := (&ssgame.ShipsAliveSalvoRule{}).Shots(opponentBoard)
+
+			salvo := HuntTargetSalvo(opponentBoard, allowed, rng)
+			assert.True(len(salvo) > 0)
+			assert.True(len(salvo) <= allowed)
+
+			salvoRes := selfBoard.ReceiveSalvo(salvo)
+			for _, shotRes := range salvoRes {
+				assert.Equal(ssgame.CoordsBlank, opponentBoard.CoordsState(shotRes.Coords))
+				opponentBoard.ApplyShotStatus(shotRes.Coords, shotRes.ShotStatus)
+			}
+
+			shots += len(salvo)
+		}
+
+		// shooting at random takes close to every cell of the board, we should do a lot better
+		assert.True(shots < rules.Width*rules.Height*3/4, "took %d shots", shots)
+	}
+}
+
+func TestHuntTargetStrategy(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	rules.Salvo = &ssgame.FixedSalvoRule{N: 3}
+
+	game, err := ssgame.CreateNewGame("game-1", &ssgame.Player{PlayerID: "player-1"}, rules, nil, ssgame.NewSeededRandomSource(1), true)
+	assert.NoError(err)
+
+	var strategy Strategy = &HuntTargetStrategy{}
+	assert.Equal(HuntTargetStrategyName, strategy.Name())
+	assert.Equal(3, len(strategy.Salvo(game)))
+}
@@ -0,0 +1,44 @@
+package ssai
+
+import (
+	"math/rand"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+)
+
+const RandomStrategyName = "random"
+
+// the dumbest strategy, fire at random at cells we haven't fired at yet
+type RandomStrategy struct{}
+
+func (s *RandomStrategy) Name() string {
+	return RandomStrategyName
+}
+
+func (s *RandomStrategy) Salvo(game *ssgame.Game) ssgame.CoordsGroup {
+	return RandomSalvo(game.OpponentBoard, game.SelfShots(), game.Rand())
+}
+
+// pick (max) shots random blank cells on the opponent board
+//  when there are less blank cells left than shots we only get the blank cells
+func RandomSalvo(board *ssgame.OpponentBoard, shots int, rng *rand.Rand) ssgame.CoordsGroup {
+	rules := board.Rules()
+
+	candidates := make(ssgame.CoordsGroup, 0, rules.Width*rules.Height)
+	for y := 0; y < rules.Height; y++ {
+		for x := 0; x < rules.Width; x++ {
+			coords := ssgame.NewCoords(x, y)
+			if board.CoordsState(coords) == ssgame.CoordsBlank {
+				candidates = append(candidates, coords)
+			}
+		}
+	}
+
+	shuffle(candidates, rng)
+
+	if len(candidates) > shots {
+		candidates = candidates[:shots]
+	}
+
+	return candidates
+}
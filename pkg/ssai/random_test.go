@@ -0,0 +1,53 @@
+package ssai
+
+import (
+	"testing"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomSalvo(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+	rules.Width = 2
+	rules.Height = 2
+	board, err := ssgame.NewBlankOpponentBoard(rules, uint8(rules.Fleet.Size()))
+	assert.NoError(err)
+
+	board.ApplyShotStatus(mustCoordsFromString("0x0"), ssgame.ShotStatusMiss)
+	board.ApplyShotStatus(mustCoordsFromString("1x1"), ssgame.ShotStatusHit)
+
+	// only the blank cells, never more than there are
+	salvo := RandomSalvo(board, 5, testRand())
+	sortCoords(salvo)
+	assert.Equal(ssgame.CoordsGroup{mustCoordsFromString("1x0"), mustCoordsFromString("0x1")}, salvo)
+
+	assert.Equal(1, len(RandomSalvo(board, 1, testRand())))
+}
+
+func TestStrategyFromDifficulty(t *testing.T) {
+	assert := require.New(t)
+
+	for difficulty, name := range map[string]string{
+		DifficultyEasy:   RandomStrategyName,
+		DifficultyMedium: HuntTargetStrategyName,
+		DifficultyHard:   ProbabilityDensityStrategyName,
+		"":               ProbabilityDensityStrategyName,
+	} {
+		strategy, err := StrategyFromDifficulty(difficulty)
+		assert.NoError(err)
+		assert.Equal(name, strategy.Name())
+
+		// every strategy can be found by it's name as well
+		strategy, err = StrategyFromName(name)
+		assert.NoError(err)
+		assert.Equal(name, strategy.Name())
+	}
+
+	_, err := StrategyFromDifficulty("bogus")
+	assert.Error(err)
+	_, err = StrategyFromName("bogus")
+	assert.Error(err)
+}
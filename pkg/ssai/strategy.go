@@ -0,0 +1,51 @@
+package ssai
+
+import (
+	"github.com/pkg/errors"
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+)
+
+// a strategy picks the coords for our next salvo in a game,
+//  it should never pick more coords than the salvo rule of the game allows
+type Strategy interface {
+	Name() string
+	Salvo(game *ssgame.Game) ssgame.CoordsGroup
+}
+
+// the strategy that's used when none is chosen
+const DefaultStrategyName = ProbabilityDensityStrategyName
+
+// the Strategy for a name
+func StrategyFromName(name string) (Strategy, error) {
+	switch name {
+	case RandomStrategyName:
+		return &RandomStrategy{}, nil
+	case HuntTargetStrategyName:
+		return &HuntTargetStrategy{}, nil
+	case ProbabilityDensityStrategyName:
+		return &ProbabilityDensityStrategy{}, nil
+	default:
+		return nil, errors.Errorf("Invalid strategy: %s", name)
+	}
+}
+
+// how smart the strategy for a hint (or an AI opponent) is
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// the Strategy for a difficulty, an empty difficulty is the hardest
+func StrategyFromDifficulty(difficulty string) (Strategy, error) {
+	switch difficulty {
+	case DifficultyEasy:
+		return &RandomStrategy{}, nil
+	case DifficultyMedium:
+		return &HuntTargetStrategy{}, nil
+	case DifficultyHard, "":
+		return &ProbabilityDensityStrategy{}, nil
+	default:
+		return nil, errors.Errorf("Invalid difficulty: %s", difficulty)
+	}
+}
@@ -0,0 +1,133 @@
+package ssclient
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rubensayshi/xlspaceship/pkg/ssai"
+)
+
+// the hostname of the AI opponents we can practice against, the strategy is appended to it
+//  so every strategy is a different opponent
+const PracticeHostname = "practice"
+
+// an AI opponent that lives in our own process, we talk to it with a MemRequester and it plays with the autopilot
+func newPracticePeer(xl *XLSpaceship, strategy ssai.Strategy) *XLSpaceship {
+	peer := NewXLSpaceship(
+		fmt.Sprintf("%s-%s", PracticeHostname, strategy.Name()),
+		fmt.Sprintf("Practice AI (%s)", strategy.Name()),
+		fmt.Sprintf("%s-%s", PracticeHostname, strategy.Name()),
+		0)
+	peer.requester = &MemRequester{xl.reqQueue}
+	peer.SetAutopilot(strategy)
+	if xl.seeds != nil {
+		peer.SetRandomSeed(xl.seeds.Int63())
+	}
+
+	go func() {
+		peer.Run()
+	}()
+
+	return peer
+}
+
+// the protocol of the AI opponent for a strategy, the opponent is started when we don't have it yet
+//  requests to it go through a practiceRequester which sends everything else to the requester we already had
+func (xl *XLSpaceship) practiceProtocol(strategyName string) (SpaceshipProtocol, error) {
+	strategy, err := ssai.StrategyFromName(strategyName)
+	if err != nil {
+		return SpaceshipProtocol{}, errors.Wrapf(err, "Failed to start practice opponent")
+	}
+
+	requester, ok := xl.requester.(*practiceRequester)
+	if !ok {
+		requester = &practiceRequester{
+			peers:     make(map[SpaceshipProtocol]Requester),
+			requester: xl.requester,
+		}
+		xl.requester = requester
+	}
+
+	dest := SpaceshipProtocol{
+		Hostname: fmt.Sprintf("%s-%s", PracticeHostname, strategy.Name()),
+	}
+
+	if !requester.hasPeer(dest) {
+		peer := newPracticePeer(xl, strategy)
+		requester.addPeer(dest, &MemRequester{peer.reqQueue})
+	}
+
+	return dest, nil
+}
+
+// start a game against the AI opponent for a strategy, it's queued like any other request so it's safe while we're running
+func (xl *XLSpaceship) InitPracticeGame(strategyName string) (string, error) {
+	if strategyName == "" {
+		strategyName = ssai.DefaultStrategyName
+	}
+
+	xlRes := xl.HandleRequest(&InitGameRequest{
+		Practice: strategyName,
+	})
+	if xlRes.err != nil {
+		return "", xlRes.err
+	}
+
+	gameID, ok := xlRes.res.(string)
+	if !ok {
+		return "", errors.Errorf("Failed to init practice game: invalid response type: %T", xlRes.res)
+	}
+
+	return gameID, nil
+}
+
+// Requester that sends requests for our practice opponents to them and everything else to the normal requester,
+//  requests to peers are sent outside of the Run loop while new opponents are added in it so the peers are locked
+type practiceRequester struct {
+	lock      sync.Mutex
+	peers     map[SpaceshipProtocol]Requester
+	requester Requester
+}
+
+func (r *practiceRequester) hasPeer(dest SpaceshipProtocol) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	_, ok := r.peers[dest]
+	return ok
+}
+
+func (r *practiceRequester) addPeer(dest SpaceshipProtocol, peer Requester) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.peers[dest] = peer
+}
+
+func (r *practiceRequester) requesterFor(dest SpaceshipProtocol) Requester {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if peer, ok := r.peers[dest]; ok {
+		return peer
+	}
+
+	return r.requester
+}
+
+func (r *practiceRequester) NewGame(dest SpaceshipProtocol, req *NewGameRequest) (*NewGameResponse, error) {
+	return r.requesterFor(dest).NewGame(dest, req)
+}
+
+func (r *practiceRequester) Ready(dest SpaceshipProtocol, req *ReadyRequest) (*ReadyResponse, error) {
+	return r.requesterFor(dest).Ready(dest, req)
+}
+
+func (r *practiceRequester) Reveal(dest SpaceshipProtocol, req *RevealRequest) (*RevealResponse, error) {
+	return r.requesterFor(dest).Reveal(dest, req)
+}
+
+func (r *practiceRequester) ReceiveSalvo(dest SpaceshipProtocol, req *ReceiveSalvoRequest) (*SalvoResponse, error) {
+	return r.requesterFor(dest).ReceiveSalvo(dest, req)
+}
@@ -0,0 +1,146 @@
+package ssclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssai"
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSpaceshipPractice(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+	xl.SetRandomSeed(1)
+
+	// let the handler run
+	go func() {
+		xl.Run()
+	}()
+
+	_, err := xl.InitPracticeGame("bogus")
+	assert.Error(err)
+
+	gameID, err := xl.InitPracticeGame(ssai.HuntTargetStrategyName)
+	assert.NoError(err)
+
+	xlRes := xl.HandleRequest(&GameStatusRequest{GameID: gameID})
+	assert.NoError(xlRes.err)
+	status := xlRes.res.(*GameStatusResponse)
+	assert.Equal("practice-hunt-target", status.Opponent.UserID)
+
+	// a second practice game is against the same opponent, other strategies are another opponent
+	_, err = xl.InitPracticeGame(ssai.HuntTargetStrategyName)
+	assert.NoError(err)
+	_, err = xl.InitPracticeGame("")
+	assert.NoError(err)
+	assert.Equal(2, len(xl.requester.(*practiceRequester).peers))
+
+	// we play our practice game with the autopilot as well, the AI opponent answers every salvo
+	xlRes = xl.HandleRequest(&AutopilotRequest{GameID: gameID, Enabled: true, Strategy: ssai.HuntTargetStrategyName})
+	assert.NoError(xlRes.err)
+
+	// the verdict is set once both boards are revealed at the end of the game
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		xlRes := xl.HandleRequest(&GameStatusRequest{GameID: gameID})
+		assert.NoError(xlRes.err)
+		status = xlRes.res.(*GameStatusResponse)
+		if status.Verdict != "" && status.Verdict != ssgame.VerdictPending.String() {
+			break
+		}
+
+		assert.True(time.Now().Before(deadline), "practice game did not finish")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.IsType(GameWonResponse{}, status.Game)
+	assert.Equal(ssgame.VerdictHonest.String(), status.Verdict)
+}
+
+func TestXLSpaceshipPracticeWhileAutopilot(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+	xl.SetRandomSeed(1)
+	xl.SetAutopilot(&ssai.HuntTargetStrategy{})
+
+	// let the handler run
+	go func() {
+		xl.Run()
+	}()
+
+	gameID, err := xl.InitPracticeGame(ssai.HuntTargetStrategyName)
+	assert.NoError(err)
+
+	// both autopilots are playing the first game while we keep starting new games against the same opponent,
+	//  which has us waiting for the opponent while it's firing salvos at us
+	gameIDs := []string{gameID}
+	for i := 0; i < 10; i++ {
+		gameID, err := xl.InitPracticeGame(ssai.HuntTargetStrategyName)
+		assert.NoError(err)
+		gameIDs = append(gameIDs, gameID)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for _, gameID := range gameIDs {
+		for {
+			xlRes := xl.HandleRequest(&GameStatusRequest{GameID: gameID})
+			assert.NoError(xlRes.err)
+			status := xlRes.res.(*GameStatusResponse)
+			if status.Verdict != "" && status.Verdict != ssgame.VerdictPending.String() {
+				assert.Equal(ssgame.VerdictHonest.String(), status.Verdict)
+				break
+			}
+
+			assert.True(time.Now().Before(deadline), "practice game did not finish")
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestXLSpaceshipPracticeManualPlacement(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+	xl.SetRandomSeed(1)
+
+	rules := ssgame.DefaultRules()
+	rules.ManualPlacement = true
+	assert.NoError(xl.SetDefaultRules(rules))
+
+	// let the handler run
+	go func() {
+		xl.Run()
+	}()
+
+	gameID, err := xl.InitPracticeGame(ssai.HuntTargetStrategyName)
+	assert.NoError(err)
+
+	// the AI opponent places it's spaceships by itself, so once we placed ours the game starts
+	xlRes := xl.HandleRequest(&PlaceBoardRequest{GameID: gameID, Random: true})
+	assert.NoError(xlRes.err)
+
+	xlRes = xl.HandleRequest(&AutopilotRequest{GameID: gameID, Enabled: true, Strategy: ssai.HuntTargetStrategyName})
+	assert.NoError(xlRes.err)
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		xlRes := xl.HandleRequest(&GameStatusRequest{GameID: gameID})
+		assert.NoError(xlRes.err)
+		status := xlRes.res.(*GameStatusResponse)
+		if status.Verdict != "" && status.Verdict != ssgame.VerdictPending.String() {
+			assert.IsType(GameWonResponse{}, status.Game)
+			assert.Equal(ssgame.VerdictHonest.String(), status.Verdict)
+			break
+		}
+
+		assert.True(time.Now().Before(deadline), "practice game did not finish")
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -13,6 +13,8 @@ import (
 
 type Requester interface {
 	NewGame(dest SpaceshipProtocol, req *NewGameRequest) (*NewGameResponse, error)
+	Ready(dest SpaceshipProtocol, req *ReadyRequest) (*ReadyResponse, error)
+	Reveal(dest SpaceshipProtocol, req *RevealRequest) (*RevealResponse, error)
 	ReceiveSalvo(dest SpaceshipProtocol, req *ReceiveSalvoRequest) (*SalvoResponse, error)
 }
 
@@ -44,6 +46,56 @@ func (r *HttpRequester) NewGame(dest SpaceshipProtocol, req *NewGameRequest) (*N
 	return newGameRes, nil
 }
 
+func (r *HttpRequester) Ready(dest SpaceshipProtocol, req *ReadyRequest) (*ReadyResponse, error) {
+	reqJson, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to request ready")
+	}
+
+	res, err := Put(fmt.Sprintf("http://%s:%d/xl-spaceship/protocol/game/%s/ready", dest.Hostname, dest.Port, req.GameID), "application/json", bytes.NewBuffer(reqJson))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to request ready")
+	}
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return nil, errors.Errorf("Failed to request ready (http: %d): %s", res.StatusCode, body)
+	}
+	defer res.Body.Close()
+
+	readyRes := &ReadyResponse{}
+	err = json.NewDecoder(res.Body).Decode(readyRes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to request ready")
+	}
+
+	return readyRes, nil
+}
+
+func (r *HttpRequester) Reveal(dest SpaceshipProtocol, req *RevealRequest) (*RevealResponse, error) {
+	reqJson, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to request reveal")
+	}
+
+	res, err := Put(fmt.Sprintf("http://%s:%d/xl-spaceship/protocol/game/%s/reveal", dest.Hostname, dest.Port, req.GameID), "application/json", bytes.NewBuffer(reqJson))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to request reveal")
+	}
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return nil, errors.Errorf("Failed to request reveal (http: %d): %s", res.StatusCode, body)
+	}
+	defer res.Body.Close()
+
+	revealRes := &RevealResponse{}
+	err = json.NewDecoder(res.Body).Decode(revealRes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to request reveal")
+	}
+
+	return revealRes, nil
+}
+
 func (r *HttpRequester) ReceiveSalvo(dest SpaceshipProtocol, req *ReceiveSalvoRequest) (*SalvoResponse, error) {
 	reqJson, err := json.Marshal(req)
 	if err != nil {
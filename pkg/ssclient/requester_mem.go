@@ -0,0 +1,95 @@
+package ssclient
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Requester that talks to another XLSpaceship in the same process through it's request queue instead of over HTTP,
+//  the destination is ignored since there's only one XLSpaceship on the other end
+type MemRequester struct {
+	reqChan chan *XLRequest
+}
+
+func (r *MemRequester) NewGame(dest SpaceshipProtocol, req *NewGameRequest) (*NewGameResponse, error) {
+	resChan := make(chan *XLResponse)
+
+	r.reqChan <- &XLRequest{
+		req:     req,
+		resChan: resChan,
+	}
+
+	xlRes := <-resChan
+	if xlRes.err != nil {
+		return nil, xlRes.err
+	}
+
+	res, ok := xlRes.res.(*NewGameResponse)
+	if !ok {
+		return nil, errors.Errorf("Failed to request new game: Invalid response type: %T", res)
+	}
+
+	return res, nil
+}
+
+func (r *MemRequester) Ready(dest SpaceshipProtocol, req *ReadyRequest) (*ReadyResponse, error) {
+	resChan := make(chan *XLResponse)
+
+	r.reqChan <- &XLRequest{
+		req:     req,
+		resChan: resChan,
+	}
+
+	xlRes := <-resChan
+	if xlRes.err != nil {
+		return nil, xlRes.err
+	}
+
+	res, ok := xlRes.res.(*ReadyResponse)
+	if !ok {
+		return nil, errors.Errorf("Failed to request ready: Invalid response type: %T", res)
+	}
+
+	return res, nil
+}
+
+func (r *MemRequester) Reveal(dest SpaceshipProtocol, req *RevealRequest) (*RevealResponse, error) {
+	resChan := make(chan *XLResponse)
+
+	r.reqChan <- &XLRequest{
+		req:     req,
+		resChan: resChan,
+	}
+
+	xlRes := <-resChan
+	if xlRes.err != nil {
+		return nil, xlRes.err
+	}
+
+	res, ok := xlRes.res.(*RevealResponse)
+	if !ok {
+		return nil, errors.Errorf("Failed to request reveal: Invalid response type: %T", res)
+	}
+
+	return res, nil
+}
+
+func (r *MemRequester) ReceiveSalvo(dest SpaceshipProtocol, req *ReceiveSalvoRequest) (*SalvoResponse, error) {
+	resChan := make(chan *XLResponse)
+
+	r.reqChan <- &XLRequest{
+		req:     req,
+		resChan: resChan,
+	}
+
+	xlRes := <-resChan
+	if xlRes.err != nil {
+		return nil, xlRes.err
+	}
+
+	res, ok := xlRes.res.(*SalvoResponse)
+	if !ok {
+		return nil, errors.Errorf("Failed to request receive salvo: Invalid response type: %T", res)
+	}
+
+	return res, nil
+}
@@ -14,6 +14,18 @@ func (r *MockRequester) NewGame(dest SpaceshipProtocol, req *NewGameRequest) (*N
 	return args.Get(0).(*NewGameResponse), args.Error(1)
 }
 
+func (r *MockRequester) Ready(dest SpaceshipProtocol, req *ReadyRequest) (*ReadyResponse, error) {
+	args := r.Called(dest, *req)
+
+	return args.Get(0).(*ReadyResponse), args.Error(1)
+}
+
+func (r *MockRequester) Reveal(dest SpaceshipProtocol, req *RevealRequest) (*RevealResponse, error) {
+	args := r.Called(dest, *req)
+
+	return args.Get(0).(*RevealResponse), args.Error(1)
+}
+
 func (r *MockRequester) ReceiveSalvo(dest SpaceshipProtocol, req *ReceiveSalvoRequest) (*SalvoResponse, error) {
 	args := r.Called(dest, *req)
 
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"sync"
 
@@ -20,8 +21,13 @@ func Serve(xl *XLSpaceship, port int, wg *sync.WaitGroup) {
 	AddNewGameHandler(xl, r)
 	AddInitGameHandler(xl, r)
 	AddGameStatusHandler(xl, r)
+	AddHintHandler(xl, r)
+	AddPlaceBoardHandler(xl, r)
+	AddReadyHandler(xl, r)
+	AddRevealHandler(xl, r)
 	AddReceiveSalvoHandler(xl, r)
 	AddFireSalvoHandler(xl, r)
+	AddAutopilotHandler(xl, r)
 
 	// add static file handler
 	ServeAddStaticHandler(r)
@@ -150,6 +156,18 @@ func AddGameStatusHandler(xl *XLSpaceship, r *mux.Router) {
 
 		req := &GameStatusRequest{GameID: gameID}
 
+		// optionally the status after a turn in the past
+		if turnStr := r.URL.Query().Get("turn"); turnStr != "" {
+			turn, err := strconv.Atoi(turnStr)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(fmt.Sprintf("Invalid turn: %s", turnStr)))
+				return
+			}
+
+			req.Turn = &turn
+		}
+
 		xlRes := xl.HandleRequest(req)
 		if xlRes.err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -181,6 +199,169 @@ func AddGameStatusHandler(xl *XLSpaceship, r *mux.Router) {
 	})
 }
 
+func AddHintHandler(xl *XLSpaceship, r *mux.Router) {
+	r.HandleFunc("/xl-spaceship/user/game/{gameID}/hint", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("%s: %s \n", r.Method, r.RequestURI)
+
+		vars := mux.Vars(r)
+		gameID := vars["gameID"]
+
+		req := &HintRequest{
+			GameID:     gameID,
+			Difficulty: r.URL.Query().Get("difficulty"),
+		}
+
+		xlRes := xl.HandleRequest(req)
+		if xlRes.err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to get hint: %s", xlRes.err)))
+			return
+		}
+
+		res, ok := xlRes.res.(*HintResponse)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to get hint: invalid response type: %T", xlRes.res)))
+			return
+		}
+
+		resJson, err := json.MarshalIndent(res, "", "    ")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(resJson)
+	})
+}
+
+func AddPlaceBoardHandler(xl *XLSpaceship, r *mux.Router) {
+	r.HandleFunc("/xl-spaceship/user/game/{gameID}/board", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("%s: %s \n", r.Method, r.RequestURI)
+
+		vars := mux.Vars(r)
+		gameID := vars["gameID"]
+
+		req := &PlaceBoardRequest{
+			GameID: gameID,
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Bad JSON"))
+			return
+		}
+
+		xlRes := xl.HandleRequest(req)
+		if xlRes.err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to place spaceships: %s", xlRes.err)))
+			return
+		}
+
+		res, ok := xlRes.res.(*GameStatusResponse)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to place spaceships: invalid response type: %T", xlRes.res)))
+			return
+		}
+
+		resJson, err := json.MarshalIndent(res, "", "    ")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(resJson)
+	})
+}
+
+func AddReadyHandler(xl *XLSpaceship, r *mux.Router) {
+	r.HandleFunc("/xl-spaceship/protocol/game/{gameID}/ready", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("%s: %s \n", r.Method, r.RequestURI)
+
+		vars := mux.Vars(r)
+		gameID := vars["gameID"]
+
+		req := &ReadyRequest{GameID: gameID}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Bad JSON"))
+			return
+		}
+
+		xlRes := xl.HandleRequest(req)
+		if xlRes.err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to mark ready: %s", xlRes.err)))
+			return
+		}
+
+		res, ok := xlRes.res.(*ReadyResponse)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to mark ready: invalid response type: %T", xlRes.res)))
+			return
+		}
+
+		resJson, err := json.MarshalIndent(res, "", "    ")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(resJson)
+	})
+}
+
+func AddRevealHandler(xl *XLSpaceship, r *mux.Router) {
+	r.HandleFunc("/xl-spaceship/protocol/game/{gameID}/reveal", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("%s: %s \n", r.Method, r.RequestURI)
+
+		vars := mux.Vars(r)
+		gameID := vars["gameID"]
+
+		req := &RevealRequest{GameID: gameID}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Bad JSON"))
+			return
+		}
+
+		xlRes := xl.HandleRequest(req)
+		if xlRes.err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to reveal: %s", xlRes.err)))
+			return
+		}
+
+		res, ok := xlRes.res.(*RevealResponse)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to reveal: invalid response type: %T", xlRes.res)))
+			return
+		}
+
+		resJson, err := json.MarshalIndent(res, "", "    ")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(resJson)
+	})
+}
+
 func AddFireSalvoHandler(xl *XLSpaceship, r *mux.Router) {
 	r.HandleFunc("/xl-spaceship/user/game/{gameID}/fire", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Printf("%s: %s \n", r.Method, r.RequestURI)
@@ -228,6 +409,49 @@ func AddFireSalvoHandler(xl *XLSpaceship, r *mux.Router) {
 	})
 }
 
+func AddAutopilotHandler(xl *XLSpaceship, r *mux.Router) {
+	r.HandleFunc("/xl-spaceship/user/game/{gameID}/autopilot", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Printf("%s: %s \n", r.Method, r.RequestURI)
+
+		vars := mux.Vars(r)
+		gameID := vars["gameID"]
+
+		req := &AutopilotRequest{
+			GameID: gameID,
+		}
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Bad JSON"))
+			return
+		}
+
+		xlRes := xl.HandleRequest(req)
+		if xlRes.err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to set autopilot: %s", xlRes.err)))
+			return
+		}
+
+		res, ok := xlRes.res.(*GameStatusResponse)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Failed to set autopilot: invalid response type: %T", xlRes.res)))
+			return
+		}
+
+		resJson, err := json.MarshalIndent(res, "", "    ")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(resJson)
+	})
+}
+
 func AddReceiveSalvoHandler(xl *XLSpaceship, r *mux.Router) {
 	r.HandleFunc("/xl-spaceship/protocol/game/{gameID}", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Printf("%s: %s \n", r.Method, r.RequestURI)
@@ -0,0 +1,146 @@
+package ssclient
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+)
+
+// where we keep our games so they survive a restart
+//  Save is called after every request that changes the state of a game, LoadAll when we start
+type GameStore interface {
+	Save(game *ssgame.Game) error
+	LoadAll() ([]*ssgame.Game, error)
+}
+
+// GameStore that only keeps the games in memory, the games are stored as JSON so that what's loaded is a copy
+//  and not the same game we're still playing
+type MemGameStore struct {
+	games map[string][]byte
+}
+
+func NewMemGameStore() *MemGameStore {
+	return &MemGameStore{
+		games: make(map[string][]byte),
+	}
+}
+
+func (s *MemGameStore) Save(game *ssgame.Game) error {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save game")
+	}
+
+	s.games[game.GameID] = data
+
+	return nil
+}
+
+func (s *MemGameStore) LoadAll() ([]*ssgame.Game, error) {
+	gameIDs := make([]string, 0, len(s.games))
+	for gameID := range s.games {
+		gameIDs = append(gameIDs, gameID)
+	}
+	sort.Strings(gameIDs)
+
+	games := make([]*ssgame.Game, len(gameIDs))
+	for i, gameID := range gameIDs {
+		games[i] = &ssgame.Game{}
+		err := json.Unmarshal(s.games[gameID], games[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to load game %s", gameID)
+		}
+	}
+
+	return games, nil
+}
+
+const fileGameStoreExt = ".json"
+
+// GameStore that keeps every game as a JSON file in a directory
+//  the game ID is escaped for the filename because it's chosen by whoever created the game, which might be our opponent
+type FileGameStore struct {
+	dir string
+}
+
+func NewFileGameStore(dir string) (*FileGameStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to create game store")
+	}
+
+	return &FileGameStore{
+		dir: dir,
+	}, nil
+}
+
+func (s *FileGameStore) Save(game *ssgame.Game) error {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save game")
+	}
+
+	// write to a temp file first and then move it in place, so a crash halfway never leaves us with a broken game
+	tmp, err := ioutil.TempFile(s.dir, "tmp-")
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save game")
+	}
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrapf(err, "Failed to save game")
+	}
+
+	err = os.Rename(tmp.Name(), filepath.Join(s.dir, url.PathEscape(game.GameID)+fileGameStoreExt))
+	if err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrapf(err, "Failed to save game")
+	}
+
+	return nil
+}
+
+func (s *FileGameStore) LoadAll() ([]*ssgame.Game, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to load games")
+	}
+
+	// ReadDir already sorts by filename
+	games := make([]*ssgame.Game, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), fileGameStoreExt) {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(s.dir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to load game %s", file.Name())
+		}
+
+		game := &ssgame.Game{}
+		err = json.Unmarshal(data, game)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to load game %s", file.Name())
+		}
+
+		games = append(games, game)
+	}
+
+	return games, nil
+}
@@ -0,0 +1,64 @@
+package ssclient
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+func testGameStore(t *testing.T, store GameStore) {
+	assert := require.New(t)
+
+	games, err := store.LoadAll()
+	assert.NoError(err)
+	assert.Equal(0, len(games))
+
+	game, err := ssgame.CreateNewGame("match-1/../1", &ssgame.Player{
+		PlayerID: "player-1",
+		FullName: "Player 1",
+	}, ssgame.DefaultRules(), nil, ssgame.NewSeededRandomSource(1), true)
+	assert.NoError(err)
+
+	assert.NoError(store.Save(game))
+
+	// saving again should overwrite the game
+	shot, err := ssgame.CoordsFromString("0x0")
+	assert.NoError(err)
+	game.SelfBoard.ReceiveSalvo(ssgame.CoordsGroup{shot})
+	assert.NoError(store.Save(game))
+
+	games, err = store.LoadAll()
+	assert.NoError(err)
+	assert.Equal(1, len(games))
+	assert.Equal(game.GameID, games[0].GameID)
+	assert.Equal(game.SelfBoard.ToPattern(), games[0].SelfBoard.ToPattern())
+
+	// the loaded game is a copy
+	assert.False(game == games[0])
+}
+
+func TestMemGameStore(t *testing.T) {
+	testGameStore(t, NewMemGameStore())
+}
+
+func TestFileGameStore(t *testing.T) {
+	assert := require.New(t)
+
+	dir, err := ioutil.TempDir("", "xlspaceship-store")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileGameStore(dir)
+	assert.NoError(err)
+
+	testGameStore(t, store)
+
+	// the game ID is escaped so the game ends up in the dir
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(err)
+	assert.Equal(1, len(files))
+	assert.Equal("match-1%2F..%2F1.json", files[0].Name())
+}
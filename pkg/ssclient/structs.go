@@ -1,6 +1,8 @@
 package ssclient
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
 )
@@ -10,6 +12,131 @@ type SpaceshipProtocol struct {
 	Port     int    `json:"port"`
 }
 
+// extensions to the protocol that both players need to support to be used,
+//  the player that starts a game offers the extensions he supports and the other player responds with the ones they both support
+//  players that don't support extensions won't send any
+const (
+	// a kill is reported as `kill:<name>` with the name of the spaceship that was killed
+	ExtensionNamedKills = "named-kills"
+)
+
+var SupportedExtensions = []string{ExtensionNamedKills}
+
+func hasExtension(extensions []string, extension string) bool {
+	for _, e := range extensions {
+		if e == extension {
+			return true
+		}
+	}
+
+	return false
+}
+
+// the extensions that are used for a game
+func gameExtensions(game *ssgame.Game) []string {
+	extensions := make([]string, 0)
+	if game.NamedKills {
+		extensions = append(extensions, ExtensionNamedKills)
+	}
+
+	return extensions
+}
+
+// the rules for a game as they're send over the wire, the rules are proposed by the player that starts the game
+//  Fleet is optional, when omitted the fleet for a standard game is used
+//  Salvo is optional, when omitted it's 1 shot per spaceship alive, SalvoShots is only used for the fixed salvo rule
+type GameRules struct {
+	Width           int                   `json:"width"`
+	Height          int                   `json:"height"`
+	Fleet           []*GameFleetSpaceship `json:"fleet,omitempty"`
+	ManualPlacement bool                  `json:"manual_placement,omitempty"`
+	NoTouch         bool                  `json:"no_touch,omitempty"`
+	Salvo           string                `json:"salvo,omitempty"`
+	SalvoShots      int                   `json:"salvo_shots,omitempty"`
+	ChainOnHit      bool                  `json:"chain_on_hit,omitempty"`
+	Mirror          bool                  `json:"mirror,omitempty"`
+}
+
+type GameFleetSpaceship struct {
+	Name    string   `json:"name"`
+	Pattern []string `json:"pattern"`
+	Count   int      `json:"count"`
+}
+
+func GameRulesFromRules(rules *ssgame.Rules) *GameRules {
+	res := &GameRules{
+		Width:           rules.Width,
+		Height:          rules.Height,
+		Fleet:           make([]*GameFleetSpaceship, len(rules.Fleet)),
+		ManualPlacement: rules.ManualPlacement,
+		NoTouch:         rules.NoTouch,
+		ChainOnHit:      rules.ChainOnHit,
+		Mirror:          rules.Mirror,
+	}
+
+	if rules.Salvo != nil {
+		res.Salvo = rules.Salvo.Name()
+		if fixed, ok := rules.Salvo.(*ssgame.FixedSalvoRule); ok {
+			res.SalvoShots = fixed.N
+		}
+	}
+
+	for i, fleetSpaceship := range rules.Fleet {
+		res.Fleet[i] = &GameFleetSpaceship{
+			Name:    fleetSpaceship.Name,
+			Pattern: fleetSpaceship.Pattern,
+			Count:   fleetSpaceship.Count,
+		}
+	}
+
+	return res
+}
+
+// turn the GameRules into ssgame.Rules and make sure they're sane
+func (r *GameRules) ToRules() (*ssgame.Rules, error) {
+	rules := &ssgame.Rules{
+		Width:           r.Width,
+		Height:          r.Height,
+		Fleet:           ssgame.BaseGameFleet(),
+		ManualPlacement: r.ManualPlacement,
+		NoTouch:         r.NoTouch,
+		Salvo:           &ssgame.ShipsAliveSalvoRule{},
+		ChainOnHit:      r.ChainOnHit,
+		Mirror:          r.Mirror,
+	}
+
+	if r.Salvo != "" {
+		salvo, err := ssgame.SalvoRuleFromName(r.Salvo, r.SalvoShots)
+		if err != nil {
+			return nil, err
+		}
+
+		rules.Salvo = salvo
+	}
+
+	if len(r.Fleet) > 0 {
+		rules.Fleet = make(ssgame.Fleet, len(r.Fleet))
+		for i, fleetSpaceship := range r.Fleet {
+			if fleetSpaceship == nil {
+				return nil, errors.New("Invalid rules: empty spaceship in fleet")
+			}
+
+			rules.Fleet[i] = &ssgame.FleetSpaceship{
+				Name:    fleetSpaceship.Name,
+				Pattern: fleetSpaceship.Pattern,
+				Count:   fleetSpaceship.Count,
+			}
+		}
+	}
+
+	err := rules.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
 type WhoAmIRequest struct {
 }
 
@@ -19,17 +146,30 @@ type WhoAmIResponse struct {
 	Games    []string `json:"games"`
 }
 
+// Rules is optional, when omitted the default rules are used
+//  Commitment is the salted hash of the board of the player starting the game, empty when the spaceships are placed manually
+//  Extensions are the protocol extensions the player starting the game supports
 type NewGameRequest struct {
 	UserID            string            `json:"user_id"`
 	FullName          string            `json:"full_name"`
 	SpaceshipProtocol SpaceshipProtocol `json:"spaceship_protocol"`
+	Rules             *GameRules        `json:"rules,omitempty"`
+	Commitment        string            `json:"commitment,omitempty"`
+	Extensions        []string          `json:"extensions,omitempty"`
 }
 
+// Rules contains the rules that were used to create the game,
+//  players that don't support rules won't send them and will have used the default rules
+//  Commitment is the salted hash of the board of the responding player, empty when the spaceships are placed manually
+//  Extensions are the protocol extensions that are used for the game, the ones both players support
 type NewGameResponse struct {
-	UserID   string `json:"user_id"`
-	FullName string `json:"full_name"`
-	GameID   string `json:"game_id"`
-	Starting string `json:"starting"`
+	UserID     string     `json:"user_id"`
+	FullName   string     `json:"full_name"`
+	GameID     string     `json:"game_id"`
+	Starting   string     `json:"starting"`
+	Rules      *GameRules `json:"rules,omitempty"`
+	Commitment string     `json:"commitment,omitempty"`
+	Extensions []string   `json:"extensions,omitempty"`
 }
 
 func NewGameResponseFromGame(s *XLSpaceship, game *ssgame.Game) *NewGameResponse {
@@ -38,6 +178,12 @@ func NewGameResponseFromGame(s *XLSpaceship, game *ssgame.Game) *NewGameResponse
 	res.UserID = s.Player.PlayerID
 	res.FullName = s.Player.FullName
 	res.GameID = game.GameID
+	res.Rules = GameRulesFromRules(game.Rules)
+	res.Extensions = gameExtensions(game)
+
+	if game.SelfCommitment != nil {
+		res.Commitment = game.SelfCommitment.Hash
+	}
 
 	if game.PlayerTurn == ssgame.PlayerSelf {
 		res.Starting = s.Player.PlayerID
@@ -48,12 +194,20 @@ func NewGameResponseFromGame(s *XLSpaceship, game *ssgame.Game) *NewGameResponse
 	return res
 }
 
+// Rules is optional, when omitted the default rules of the player are used
+// when Practice is set the game is against an AI opponent in our own process using that strategy
+//  instead of the player at SpaceshipProtocol
 type InitGameRequest struct {
 	SpaceshipProtocol SpaceshipProtocol `json:"spaceship_protocol"`
+	Rules             *GameRules        `json:"rules,omitempty"`
+	Practice          string            `json:"practice,omitempty"`
+	Placement         string            `json:"placement,omitempty"`
 }
 
+// when Turn is set the status is of the game as it was after that turn
 type GameStatusRequest struct {
 	GameID string `json:"game_id"`
+	Turn   *int   `json:"turn,omitempty"`
 }
 
 type GamePlayerTurnResponse struct {
@@ -64,37 +218,68 @@ type GameWonResponse struct {
 	Won string `json:"won"`
 }
 
+type GamePlacingResponse struct {
+	Placing       bool `json:"placing"`
+	SelfReady     bool `json:"self_ready"`
+	OpponentReady bool `json:"opponent_ready"`
+}
+
+// Verdict is only set when the game is done, it tells if our opponent's revealed board checked out,
+//  when it didn't Cheat contains the reason why
 type GameStatusResponse struct {
-	GameID   string                   `json:"game_id"`
-	Self     GameStatusResponsePlayer `json:"self"`
-	Opponent GameStatusResponsePlayer `json:"opponent"`
-	Game     interface{}              `json:"game"`
+	GameID    string                   `json:"game_id"`
+	Rules     *GameRules               `json:"rules"`
+	Self      GameStatusResponsePlayer `json:"self"`
+	Opponent  GameStatusResponsePlayer `json:"opponent"`
+	Game      interface{}              `json:"game"`
+	Verdict   string                   `json:"verdict,omitempty"`
+	Cheat     string                   `json:"cheat,omitempty"`
+	Turn      int                      `json:"turn"`
+	Turns     int                      `json:"turns"`
+	Autopilot string                   `json:"autopilot,omitempty"`
 }
 
+// Fleet are the names of the spaceships that are alive, for our opponent it's null when we don't know which spaceships we killed
 type GameStatusResponsePlayer struct {
 	UserID string   `json:"user_id"`
 	Board  []string `json:"board"`
 	Shots  int      `json:"shots"`
+	Fleet  []string `json:"fleet"`
 }
 
 func GameStatusResponseFromGame(s *XLSpaceship, game *ssgame.Game) *GameStatusResponse {
 	res := &GameStatusResponse{
 		GameID: game.GameID,
+		Rules:  GameRulesFromRules(game.Rules),
+		Turn:   game.Turns(),
+		Turns:  game.Turns(),
+	}
+
+	if strategy := s.autopilotStrategy(game.GameID); strategy != nil {
+		res.Autopilot = strategy.Name()
 	}
 
 	res.Self = GameStatusResponsePlayer{
 		UserID: s.Player.PlayerID,
 		Board:  game.SelfBoard.ToPattern(),
-		Shots:  game.SelfBoard.CountShipsAlive(),
+		Shots:  game.SelfShots(),
+		Fleet:  game.SelfBoard.FleetAlive(),
 	}
 
 	res.Opponent = GameStatusResponsePlayer{
 		UserID: game.Opponent.PlayerID,
 		Board:  game.OpponentBoard.ToPattern(),
-		Shots:  game.OpponentBoard.CountShipsAlive(),
+		Shots:  game.OpponentShots(),
+		Fleet:  game.OpponentBoard.FleetAlive(),
 	}
 
-	if game.Status == ssgame.GameStatusDone {
+	if game.Status == ssgame.GameStatusPlacing {
+		res.Game = GamePlacingResponse{
+			Placing:       true,
+			SelfReady:     game.SelfReady,
+			OpponentReady: game.OpponentReady,
+		}
+	} else if game.Status == ssgame.GameStatusDone {
 		won := s.Player.PlayerID
 		if game.PlayerWon == ssgame.PlayerOpponent {
 			won = game.Opponent.PlayerID
@@ -103,6 +288,9 @@ func GameStatusResponseFromGame(s *XLSpaceship, game *ssgame.Game) *GameStatusRe
 		res.Game = GameWonResponse{
 			Won: won,
 		}
+
+		res.Verdict = game.Verdict.String()
+		res.Cheat = game.CheatReason
 	} else {
 		playerTurn := s.Player.PlayerID
 		if game.PlayerTurn == ssgame.PlayerOpponent {
@@ -117,6 +305,67 @@ func GameStatusResponseFromGame(s *XLSpaceship, game *ssgame.Game) *GameStatusRe
 	return res
 }
 
+// place our spaceships on our board, either by drawing them on a pattern of the board,
+//  by specifying where each spaceship goes or by letting them be placed randomly
+type PlaceBoardRequest struct {
+	GameID     string                `json:"-"`
+	Board      []string              `json:"board,omitempty"`
+	Placements []*SpaceshipPlacement `json:"placements,omitempty"`
+	Random     bool                  `json:"random,omitempty"`
+	Placement  string                `json:"placement,omitempty"`
+}
+
+type SpaceshipPlacement struct {
+	Spaceship string `json:"spaceship"`
+	Offset    string `json:"offset"`
+	Rotation  uint16 `json:"rotation"`
+	Mirror    bool   `json:"mirror,omitempty"`
+}
+
+// let our opponent know we've placed our spaceships, together with the commitment to our board
+type ReadyRequest struct {
+	GameID     string `json:"-"`
+	Commitment string `json:"commitment,omitempty"`
+}
+
+// Ready indicates if the player that responds has placed his spaceships, if so Commitment is the commitment to his board
+type ReadyResponse struct {
+	GameID     string `json:"game_id"`
+	Ready      bool   `json:"ready"`
+	Commitment string `json:"commitment,omitempty"`
+}
+
+// reveal our board and the salt of our commitment at the end of the game, our opponent responds with his
+type RevealRequest struct {
+	GameID string   `json:"-"`
+	Board  []string `json:"board"`
+	Salt   string   `json:"salt"`
+}
+
+type RevealResponse struct {
+	GameID string   `json:"game_id"`
+	Board  []string `json:"board"`
+	Salt   string   `json:"salt"`
+}
+
+// Strategy is the name of the strategy the autopilot uses, the default strategy is used when it's empty
+type AutopilotRequest struct {
+	GameID   string `json:"-"`
+	Enabled  bool   `json:"enabled"`
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// Difficulty decides the strategy that picks the salvo, see ssai.StrategyFromDifficulty
+type HintRequest struct {
+	GameID     string `json:"-"`
+	Difficulty string `json:"difficulty"`
+}
+
+type HintResponse struct {
+	Salvo    []string `json:"salvo"`
+	Strategy string   `json:"strategy"`
+}
+
 type FireSalvoRequest struct {
 	GameID string   `json:"-"`
 	Salvo  []string `json:"salvo"`
@@ -128,21 +377,28 @@ type ReceiveSalvoRequest struct {
 }
 
 // @TODO: should make custom JSON marshall/unmarshall for the "game" field instead of the hacky way we do now
+//  Proofs contains the proof of the state of the cell for every shot, so the shooter can verify the results against our commitment
 type SalvoResponse struct {
 	Salvo           map[string]string       `json:"salvo"`
 	Game            map[string]string       `json:"game"`
+	Proofs          map[string]*ShotProof   `json:"proofs,omitempty"`
 	GameWon         *GameWonResponse        `json:"-"`
 	GamePlayerTurn  *GamePlayerTurnResponse `json:"-"`
 	AlreadyFinished bool                    `json:"-"`
 }
 
+type ShotProof struct {
+	Salt  string   `json:"salt"`
+	Proof []string `json:"proof"`
+}
+
 func SalvoResponseFromSalvoResult(salvoResult []*ssgame.ShotResult, xl *XLSpaceship, game *ssgame.Game) *SalvoResponse {
 	res := &SalvoResponse{
 		Salvo: make(map[string]string, len(salvoResult)),
 	}
 
 	for _, shotResult := range salvoResult {
-		res.Salvo[shotResult.Coords.String()] = shotResult.ShotStatus.String()
+		res.Salvo[shotResult.Coords.String()] = shotResultToString(shotResult, game.NamedKills)
 	}
 
 	if game.Status == ssgame.GameStatusDone {
@@ -163,6 +419,30 @@ func SalvoResponseFromSalvoResult(salvoResult []*ssgame.ShotResult, xl *XLSpaces
 	return res
 }
 
+// the result of a shot as it's send over the wire, with the name of the spaceship for a kill when named is true
+func shotResultToString(shotResult *ssgame.ShotResult, named bool) string {
+	if named && shotResult.ShotStatus == ssgame.ShotStatusKill && shotResult.Spaceship != "" {
+		return shotResult.ShotStatus.String() + ":" + shotResult.Spaceship
+	}
+
+	return shotResult.ShotStatus.String()
+}
+
+// parse the result of a shot as it's send over the wire, a kill can have the name of the spaceship
+func shotResultFromString(coords *ssgame.Coords, shotResultStr string) (*ssgame.ShotResult, error) {
+	name := ""
+	if strings.HasPrefix(shotResultStr, ssgame.ShotStatusKillStr+":") {
+		shotResultStr, name = ssgame.ShotStatusKillStr, strings.TrimPrefix(shotResultStr, ssgame.ShotStatusKillStr+":")
+	}
+
+	shotStatus, err := ssgame.ShotStatusFromString(shotResultStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssgame.ShotResult{Coords: coords, ShotStatus: shotStatus, Spaceship: name}, nil
+}
+
 func (r *SalvoResponse) Normalize() error {
 	if playerIdWon, won := r.Game["won"]; won {
 		r.GameWon = &GameWonResponse{
@@ -0,0 +1,322 @@
+package ssclient
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rubensayshi/xlspaceship/pkg/ssai"
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+)
+
+// z-score for the 95% confidence intervals in the tournament results
+const tournamentZ = 1.96
+
+// the outcome of a single game between 2 strategies
+//  Winner is the index of the strategy that won, Salvos is the number of salvos the winner needed
+//  and Turns is the number of salvos fired by both strategies
+type MatchResult struct {
+	Winner int
+	Salvos int
+	Turns  int
+}
+
+// play a game between 2 strategies, each with their own XLSpaceship talking to the other with a MemRequester
+//  the first strategy starts the game, but who's first to fire is decided by the other like in any other game
+//  the seed decides everything that's random, so the same seed always gives the same game
+func PlayMatch(rules *ssgame.Rules, strategies [2]ssai.Strategy, seed int64) (*MatchResult, error) {
+	seeds := rand.New(ssgame.NewSeededRandomSource(seed))
+
+	xls := [2]*XLSpaceship{
+		NewXLSpaceship("tournament-1", fmt.Sprintf("Player 1 (%s)", strategies[0].Name()), "tournament-1", 1),
+		NewXLSpaceship("tournament-2", fmt.Sprintf("Player 2 (%s)", strategies[1].Name()), "tournament-2", 2),
+	}
+	xls[0].requester = &MemRequester{xls[1].reqQueue}
+	xls[1].requester = &MemRequester{xls[0].reqQueue}
+
+	wg := &sync.WaitGroup{}
+	for _, xl := range xls {
+		xl.SetRandomSeed(seeds.Int63())
+
+		wg.Add(1)
+		go func(xl *XLSpaceship) {
+			xl.Run()
+			wg.Done()
+		}(xl)
+	}
+
+	// nothing is queued anymore once we return and the boards are revealed, so stopping the handlers frees the games
+	defer func() {
+		for _, xl := range xls {
+			xl.waitForPeers()
+		}
+		for _, xl := range xls {
+			close(xl.reqQueue)
+		}
+		wg.Wait()
+	}()
+
+	xlRes := xls[0].HandleRequest(&InitGameRequest{
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: xls[1].Player.ProtocolHost,
+			Port:     xls[1].Player.ProtocolPort,
+		},
+		Rules: GameRulesFromRules(rules),
+	})
+	if xlRes.err != nil {
+		return nil, errors.Wrapf(xlRes.err, "Failed to play match")
+	}
+	gameID := xlRes.res.(string)
+
+	// when the rules say the spaceships are placed manually both players place them on a random board
+	if rules.ManualPlacement {
+		for _, xl := range xls {
+			xlRes := xl.HandleRequest(&PlaceBoardRequest{GameID: gameID, Random: true})
+			if xlRes.err != nil {
+				return nil, errors.Wrapf(xlRes.err, "Failed to play match")
+			}
+		}
+	}
+
+	xlRes = xls[0].HandleRequest(&GameStatusRequest{GameID: gameID})
+	if xlRes.err != nil {
+		return nil, errors.Wrapf(xlRes.err, "Failed to play match")
+	}
+	playerTurn, ok := xlRes.res.(*GameStatusResponse).Game.(GamePlayerTurnResponse)
+	if !ok {
+		return nil, errors.Errorf("Failed to play match: game did not start")
+	}
+
+	player := 0
+	if playerTurn.PlayerTurn != xls[0].Player.PlayerID {
+		player = 1
+	}
+
+	// every salvo hits at least one cell that wasn't hit before, so a game can't take longer than this
+	maxSalvos := 2*rules.Width*rules.Height + 1
+
+	for salvos := 0; salvos < maxSalvos; salvos++ {
+		xlRes := xls[player].HandleRequest(&autopilotFireRequest{
+			GameID:   gameID,
+			Strategy: strategies[player],
+		})
+		if xlRes.err != nil {
+			return nil, errors.Wrapf(xlRes.err, "Failed to play match")
+		}
+
+		res, ok := xlRes.res.(*SalvoResponse)
+		if !ok || res == nil {
+			return nil, errors.Errorf("Failed to play match: invalid response type: %T", xlRes.res)
+		}
+
+		if res.GameWon != nil {
+			game := xls[player].games[gameID]
+
+			return &MatchResult{
+				Winner: player,
+				Salvos: len(game.SelfSalvos),
+				Turns:  game.Turns(),
+			}, nil
+		}
+
+		if res.GamePlayerTurn.PlayerTurn != xls[player].Player.PlayerID {
+			player = 1 - player
+		}
+	}
+
+	return nil, errors.Errorf("Failed to play match: game did not finish after %d salvos", maxSalvos)
+}
+
+// how a strategy did in a tournament, the intervals are 95% confidence intervals
+type StrategyResult struct {
+	Name         string
+	Games        int
+	Wins         int
+	WinRate      float64
+	WinRateLow   float64
+	WinRateHigh  float64
+	AvgSalvos    float64
+	AvgSalvosErr float64
+
+	salvos []int
+}
+
+// how 2 strategies did against each other, the win rate is of the first strategy
+//  and the average turns is how many salvos a game between them took, the intervals are 95% confidence intervals
+type MatchupResult struct {
+	Strategies  [2]string
+	Games       int
+	Wins        [2]int
+	WinRate     float64
+	WinRateLow  float64
+	WinRateHigh float64
+	AvgTurns    float64
+	AvgTurnsErr float64
+
+	turns []int
+}
+
+type TournamentResult struct {
+	Strategies []*StrategyResult
+	Matchups   []*MatchupResult
+}
+
+// let every strategy play every other strategy, games times for every matchup
+//  the games are played in parallel but the seed still decides the outcome of every game
+//  the strategies take turns to start the game so neither gets an advantage from it
+func RunTournament(rules *ssgame.Rules, strategies []ssai.Strategy, games int, seed int64) (*TournamentResult, error) {
+	if len(strategies) < 2 {
+		return nil, errors.Errorf("Failed to run tournament: need at least 2 strategies")
+	}
+	if games < 1 {
+		return nil, errors.Errorf("Failed to run tournament: need at least 1 game per matchup")
+	}
+
+	err := rules.Validate()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to run tournament")
+	}
+
+	type match struct {
+		matchup    int
+		first      int
+		players    [2]int
+		strategies [2]ssai.Strategy
+		seed       int64
+		res        *MatchResult
+		err        error
+	}
+
+	seeds := rand.New(ssgame.NewSeededRandomSource(seed))
+
+	result := &TournamentResult{
+		Strategies: make([]*StrategyResult, len(strategies)),
+	}
+	for i, strategy := range strategies {
+		result.Strategies[i] = &StrategyResult{Name: strategy.Name()}
+	}
+
+	matches := make([]*match, 0)
+	for i := range strategies {
+		for j := i + 1; j < len(strategies); j++ {
+			result.Matchups = append(result.Matchups, &MatchupResult{
+				Strategies: [2]string{strategies[i].Name(), strategies[j].Name()},
+			})
+
+			for n := 0; n < games; n++ {
+				players := [2]int{i, j}
+				if n%2 == 1 {
+					players = [2]int{j, i}
+				}
+
+				matches = append(matches, &match{
+					matchup:    len(result.Matchups) - 1,
+					first:      i,
+					players:    players,
+					strategies: [2]ssai.Strategy{strategies[players[0]], strategies[players[1]]},
+					seed:       seeds.Int63(),
+				})
+			}
+		}
+	}
+
+	queue := make(chan *match)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			for m := range queue {
+				m.res, m.err = PlayMatch(rules, m.strategies, m.seed)
+			}
+			wg.Done()
+		}()
+	}
+
+	for _, m := range matches {
+		queue <- m
+	}
+	close(queue)
+	wg.Wait()
+
+	for _, m := range matches {
+		if m.err != nil {
+			return nil, errors.Wrapf(m.err, "Failed to run tournament")
+		}
+
+		matchup := result.Matchups[m.matchup]
+		matchup.Games++
+		matchup.turns = append(matchup.turns, m.res.Turns)
+
+		winner := m.players[m.res.Winner]
+		if winner == m.first {
+			matchup.Wins[0]++
+		} else {
+			matchup.Wins[1]++
+		}
+
+		for _, player := range m.players {
+			result.Strategies[player].Games++
+		}
+		result.Strategies[winner].Wins++
+		result.Strategies[winner].salvos = append(result.Strategies[winner].salvos, m.res.Salvos)
+	}
+
+	for _, strategy := range result.Strategies {
+		strategy.WinRate = float64(strategy.Wins) / float64(strategy.Games)
+		strategy.WinRateLow, strategy.WinRateHigh = WilsonInterval(strategy.Wins, strategy.Games, tournamentZ)
+		strategy.AvgSalvos, strategy.AvgSalvosErr = MeanInterval(strategy.salvos, tournamentZ)
+	}
+
+	for _, matchup := range result.Matchups {
+		matchup.WinRate = float64(matchup.Wins[0]) / float64(matchup.Games)
+		matchup.WinRateLow, matchup.WinRateHigh = WilsonInterval(matchup.Wins[0], matchup.Games, tournamentZ)
+		matchup.AvgTurns, matchup.AvgTurnsErr = MeanInterval(matchup.turns, tournamentZ)
+	}
+
+	return result, nil
+}
+
+// the Wilson score interval for a win rate, which unlike the normal approximation behaves for win rates close to 0 or 1
+func WilsonInterval(wins int, games int, z float64) (float64, float64) {
+	if games == 0 {
+		return 0, 1
+	}
+
+	n := float64(games)
+	p := float64(wins) / n
+
+	center := (p + z*z/(2*n)) / (1 + z*z/n)
+	margin := z / (1 + z*z/n) * math.Sqrt(p*(1-p)/n+z*z/(4*n*n))
+
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// the mean of the values and the margin of the confidence interval around it
+func MeanInterval(values []int, z float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	n := float64(len(values))
+
+	sum := 0.0
+	for _, v := range values {
+		sum += float64(v)
+	}
+	mean := sum / n
+
+	if len(values) == 1 {
+		return mean, 0
+	}
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (float64(v) - mean) * (float64(v) - mean)
+	}
+	variance /= n - 1
+
+	return mean, z * math.Sqrt(variance/n)
+}
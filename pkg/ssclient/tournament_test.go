@@ -0,0 +1,84 @@
+package ssclient
+
+import (
+	"testing"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssai"
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayMatch(t *testing.T) {
+	assert := require.New(t)
+
+	strategies := [2]ssai.Strategy{&ssai.HuntTargetStrategy{}, &ssai.ProbabilityDensityStrategy{}}
+
+	res, err := PlayMatch(ssgame.DefaultRules(), strategies, 1)
+	assert.NoError(err)
+	assert.True(res.Salvos > 0)
+	assert.True(res.Turns >= 2*res.Salvos-1)
+
+	// the same seed gives the same game
+	again, err := PlayMatch(ssgame.DefaultRules(), strategies, 1)
+	assert.NoError(err)
+	assert.Equal(res, again)
+
+	// when the spaceships are placed manually both players get a random board
+	rules := ssgame.DefaultRules()
+	rules.ManualPlacement = true
+	res, err = PlayMatch(rules, strategies, 1)
+	assert.NoError(err)
+	assert.True(res.Salvos > 0)
+}
+
+func TestRunTournament(t *testing.T) {
+	assert := require.New(t)
+
+	rules := ssgame.DefaultRules()
+
+	strategies := []ssai.Strategy{&ssai.HuntTargetStrategy{}, &ssai.ProbabilityDensityStrategy{}, &ssai.HuntTargetStrategy{}}
+
+	_, err := RunTournament(rules, strategies[:1], 10, 1)
+	assert.Error(err)
+
+	res, err := RunTournament(rules, strategies, 4, 1)
+	assert.NoError(err)
+	assert.Equal(3, len(res.Strategies))
+	assert.Equal(3, len(res.Matchups))
+
+	wins := 0
+	for _, strategy := range res.Strategies {
+		assert.Equal(8, strategy.Games)
+		assert.True(strategy.WinRateLow <= strategy.WinRate && strategy.WinRate <= strategy.WinRateHigh)
+		wins += strategy.Wins
+	}
+	assert.Equal(12, wins)
+
+	for _, matchup := range res.Matchups {
+		assert.Equal(4, matchup.Games)
+		assert.Equal(4, matchup.Wins[0]+matchup.Wins[1])
+		assert.True(matchup.AvgTurns > 0)
+		assert.True(matchup.AvgTurnsErr >= 0)
+	}
+
+	// the same seed gives the same tournament
+	again, err := RunTournament(rules, strategies, 4, 1)
+	assert.NoError(err)
+	assert.Equal(res, again)
+}
+
+func TestWilsonInterval(t *testing.T) {
+	assert := require.New(t)
+
+	low, high := WilsonInterval(50, 100, 1.96)
+	assert.InDelta(0.404, low, 0.001)
+	assert.InDelta(0.596, high, 0.001)
+
+	low, high = WilsonInterval(0, 10, 1.96)
+	assert.Equal(0.0, low)
+	assert.InDelta(0.278, high, 0.001)
+
+	mean, margin := MeanInterval([]int{1, 2, 3, 4}, 1.96)
+	assert.Equal(2.5, mean)
+	assert.InDelta(1.265, margin, 0.001)
+}
@@ -9,7 +9,14 @@ import (
 
 	"fmt"
 
+	"reflect"
+
+	"strconv"
+	"strings"
+	"sync"
+
 	"github.com/pkg/errors"
+	"github.com/rubensayshi/xlspaceship/pkg/ssai"
 	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
 )
 
@@ -26,10 +33,31 @@ type XLResponse struct {
 type XLSpaceship struct {
 	Player      *ssgame.Player
 	games       map[string]*ssgame.Game
+	store       GameStore
+	rules       *ssgame.Rules
 	requester   Requester
 	cheat       bool
 	reqQueue    chan *XLRequest
 	matchIDIncr uint
+	seeds       *rand.Rand
+	autopilot   ssai.Strategy
+	autopilots  map[string]ssai.Strategy
+	firing      map[string][]*XLRequest
+	pending     sync.WaitGroup
+	placement   ssgame.PlacementStrategy
+}
+
+// internal request to let the autopilot fire a salvo for a game, it's queued like any other request
+//  so the autopilot never fires while we're still handling another request
+//  when Strategy is set it's used instead of the autopilot's strategy, so the salvo can be fired with the autopilot off
+type autopilotFireRequest struct {
+	GameID   string
+	Strategy ssai.Strategy
+}
+
+// internal request with the response of a request we sent to a peer outside of the Run loop, see requestPeer
+type peerResponseRequest struct {
+	done func()
 }
 
 func NewXLSpaceship(playerID string, playerName string, host string, port int) *XLSpaceship {
@@ -40,26 +68,96 @@ func NewXLSpaceship(playerID string, playerName string, host string, port int) *
 			ProtocolHost: host,
 			ProtocolPort: port,
 		},
-		games:     make(map[string]*ssgame.Game),
-		requester: &HttpRequester{},
-		reqQueue:  make(chan *XLRequest, 1),
+		games:      make(map[string]*ssgame.Game),
+		store:      NewMemGameStore(),
+		rules:      ssgame.DefaultRules(),
+		requester:  &HttpRequester{},
+		reqQueue:   make(chan *XLRequest, 1),
+		autopilots: make(map[string]ssai.Strategy),
+		firing:     make(map[string][]*XLRequest),
 	}
 
-	// make a seed based on the playerID, that way it's deterministic but different per player
-	//  this is purely for easy debugging because this way every time we restart the game all random things will be the same
-	var seed int64 = 0
-	for _, char := range playerID {
-		seed += int64(char)
+	return s
+}
+
+// create a XLSpaceship that keeps it's games in the store and reload the games that are in the store already
+func NewXLSpaceshipWithStore(playerID string, playerName string, host string, port int, store GameStore) (*XLSpaceship, error) {
+	s := NewXLSpaceship(playerID, playerName, host, port)
+	s.store = store
+
+	games, err := store.LoadAll()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to reload games")
 	}
-	rand.Seed(seed)
 
-	return s
+	// continue numbering the games we created after the ones we already have, so we don't reuse a game ID
+	prefix := fmt.Sprintf("match-%s-", playerID)
+	for _, game := range games {
+		s.games[game.GameID] = game
+
+		if strings.HasPrefix(game.GameID, prefix) {
+			matchID, err := strconv.ParseUint(strings.TrimPrefix(game.GameID, prefix), 10, 64)
+			if err == nil && uint(matchID) > s.matchIDIncr {
+				s.matchIDIncr = uint(matchID)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// make all randomness deterministic, every game gets it's own source seeded from this seed
+//  this is purely for tests and easy debugging, by default every game gets a crypto seeded source
+func (xl *XLSpaceship) SetRandomSeed(seed int64) {
+	xl.seeds = rand.New(ssgame.NewSeededRandomSource(seed))
+}
+
+// the random source for a new game
+func (xl *XLSpaceship) newRandomSource() rand.Source {
+	if xl.seeds == nil {
+		return ssgame.NewRandomSource()
+	}
+
+	return ssgame.NewSeededRandomSource(xl.seeds.Int63())
+}
+
+// let the autopilot fire our salvos with the strategy for every game, nil turns it off
+//  the autopilot can still be turned on or off for a single game with an AutopilotRequest
+func (xl *XLSpaceship) SetAutopilot(strategy ssai.Strategy) {
+	xl.autopilot = strategy
+}
+
+// place our spaceships with the strategy for every game, nil means every position is as likely
+//  the strategy can still be picked for a single game with an InitGameRequest or a random PlaceBoardRequest
+func (xl *XLSpaceship) SetPlacementStrategy(strategy ssgame.PlacementStrategy) {
+	xl.placement = strategy
+}
+
+// the placement strategy with the name, or the strategy for every game when there's no name
+func (xl *XLSpaceship) placementStrategy(name string) (ssgame.PlacementStrategy, error) {
+	if name == "" {
+		return xl.placement, nil
+	}
+
+	return ssgame.PlacementStrategyFromName(name)
 }
 
 func (xl *XLSpaceship) EnableCheatMode() {
 	xl.cheat = true
 }
 
+// set the rules that are used for games we start when the InitGameRequest doesn't specify any
+func (xl *XLSpaceship) SetDefaultRules(rules *ssgame.Rules) error {
+	err := rules.Validate()
+	if err != nil {
+		return err
+	}
+
+	xl.rules = rules
+
+	return nil
+}
+
 func (xl *XLSpaceship) NewGameID() string {
 	xl.matchIDIncr++
 	return fmt.Sprintf("match-%s-%d", xl.Player.PlayerID, xl.matchIDIncr)
@@ -67,36 +165,193 @@ func (xl *XLSpaceship) NewGameID() string {
 
 func (xl *XLSpaceship) Run() {
 	for xlReq := range xl.reqQueue {
-		switch xlReq.req.(type) {
-		case *WhoAmIRequest:
-			res, err := xl.WhoAmIRequest(xlReq.req.(*WhoAmIRequest))
-			xlReq.resChan <- &XLResponse{res, err}
+		xl.handle(xlReq)
+	}
+}
 
-		case *NewGameRequest:
-			res, err := xl.NewGameRequest(xlReq.req.(*NewGameRequest))
-			xlReq.resChan <- &XLResponse{res, err}
+// handle a request from the queue, every request gets it's response on it's resChan
+//  but not always right away (eg. the autopilot's salvo is answered when our opponent responded to it)
+func (xl *XLSpaceship) handle(xlReq *XLRequest) {
+	switch xlReq.req.(type) {
+	case *WhoAmIRequest:
+		res, err := xl.WhoAmIRequest(xlReq.req.(*WhoAmIRequest))
+		xlReq.resChan <- &XLResponse{res, err}
+
+	case *NewGameRequest:
+		res, err := xl.NewGameRequest(xlReq.req.(*NewGameRequest))
+		if err == nil {
+			err = xl.saveGame(res.GameID)
+			xl.maybeAutopilot(res.GameID)
+		}
+		xlReq.resChan <- &XLResponse{res, err}
 
-		case *InitGameRequest:
-			res, err := xl.InitNewGameRequest(xlReq.req.(*InitGameRequest))
-			xlReq.resChan <- &XLResponse{res, err}
+	case *InitGameRequest:
+		res, err := xl.InitNewGameRequest(xlReq.req.(*InitGameRequest))
+		if err == nil {
+			err = xl.saveGame(res)
+			xl.maybeAutopilot(res)
+		}
+		xlReq.resChan <- &XLResponse{res, err}
+
+	case *GameStatusRequest:
+		res, err := xl.GameStatusRequest(xlReq.req.(*GameStatusRequest))
+		xlReq.resChan <- &XLResponse{res, err}
+
+	case *HintRequest:
+		res, err := xl.HintRequest(xlReq.req.(*HintRequest))
+		xlReq.resChan <- &XLResponse{res, err}
+
+	case *PlaceBoardRequest:
+		req := xlReq.req.(*PlaceBoardRequest)
+		res, err := xl.PlaceBoardRequest(req)
+		xlReq.resChan <- xl.finishGameRequest(req.GameID, res, err)
+
+	case *ReadyRequest:
+		req := xlReq.req.(*ReadyRequest)
+		res, err := xl.ReadyRequest(req)
+		xlReq.resChan <- xl.finishGameRequest(req.GameID, res, err)
+
+	case *RevealRequest:
+		req := xlReq.req.(*RevealRequest)
+		if xl.waitForSalvo(req.GameID, xlReq) {
+			return
+		}
+		res, err := xl.RevealRequest(req)
+		xlReq.resChan <- xl.finishGameRequest(req.GameID, res, err)
 
-		case *GameStatusRequest:
-			res, err := xl.GameStatusRequest(xlReq.req.(*GameStatusRequest))
-			xlReq.resChan <- &XLResponse{res, err}
+	case *ReceiveSalvoRequest:
+		req := xlReq.req.(*ReceiveSalvoRequest)
+		if xl.waitForSalvo(req.GameID, xlReq) {
+			return
+		}
+		res, err := xl.ReceiveSalvoRequest(req)
+		xlReq.resChan <- xl.finishGameRequest(req.GameID, res, err)
+		xl.maybeRevealLost(req.GameID)
+
+	case *FireSalvoRequest:
+		req := xlReq.req.(*FireSalvoRequest)
+		res, err := xl.FireSalvoRequest(req)
+		xlReq.resChan <- xl.finishGameRequest(req.GameID, res, err)
+
+	case *AutopilotRequest:
+		req := xlReq.req.(*AutopilotRequest)
+		res, err := xl.AutopilotRequest(req)
+		xlReq.resChan <- xl.finishGameRequest(req.GameID, res, err)
+
+	case *autopilotFireRequest:
+		req := xlReq.req.(*autopilotFireRequest)
+		xl.autopilotFire(req.GameID, req.Strategy, xlReq.resChan)
+
+	case *peerResponseRequest:
+		xlReq.req.(*peerResponseRequest).done()
+		xlReq.resChan <- &XLResponse{nil, nil}
+
+	default:
+		panic(fmt.Sprintf("Invalid request type: %T", xlReq.req))
+	}
+}
 
-		case *ReceiveSalvoRequest:
-			res, err := xl.ReceiveSalvoRequest(xlReq.req.(*ReceiveSalvoRequest))
-			xlReq.resChan <- &XLResponse{res, err}
+// when we're still waiting for our opponent to respond to our salvo the request for the game is held until we have,
+//  our opponent can respond and then send his next salvo before we handled his response
+func (xl *XLSpaceship) waitForSalvo(gameID string, xlReq *XLRequest) bool {
+	if _, ok := xl.firing[gameID]; !ok {
+		return false
+	}
 
-		case *FireSalvoRequest:
-			res, err := xl.FireSalvoRequest(xlReq.req.(*FireSalvoRequest))
-			xlReq.resChan <- &XLResponse{res, err}
+	xl.firing[gameID] = append(xl.firing[gameID], xlReq)
 
-		default:
-			panic(fmt.Sprintf("Invalid request type: %T", xlReq.req))
-		}
+	return true
+}
 
+// our salvo for the game has been handled, the requests that waited for it are handled now
+func (xl *XLSpaceship) doneFiring(gameID string) {
+	waiting := xl.firing[gameID]
+	delete(xl.firing, gameID)
+
+	for _, xlReq := range waiting {
+		xl.handle(xlReq)
+	}
+}
+
+// write the game through to the store
+func (xl *XLSpaceship) saveGame(gameID string) error {
+	game, ok := xl.games[gameID]
+	if !ok {
+		return nil
+	}
+
+	err := xl.store.Save(game)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save game %s", gameID)
+	}
+
+	return nil
+}
+
+// write the game through to the store, also when the request failed because a failed request can still change the game
+//  (eg. when our opponent cheated), when saving fails that's the error for the request
+//  when the request went well and it's our turn the autopilot can take over
+func (xl *XLSpaceship) finishGameRequest(gameID string, res interface{}, err error) *XLResponse {
+	saveErr := xl.saveGame(gameID)
+	if err == nil && saveErr != nil {
+		return &XLResponse{nil, saveErr}
+	}
+
+	if err == nil {
+		xl.maybeAutopilot(gameID)
+	}
+
+	return &XLResponse{res, err}
+}
+
+// the strategy of the autopilot for a game, nil when the autopilot is off
+func (xl *XLSpaceship) autopilotStrategy(gameID string) ssai.Strategy {
+	if strategy, ok := xl.autopilots[gameID]; ok {
+		return strategy
 	}
+
+	return xl.autopilot
+}
+
+// queue a salvo for the autopilot when it's on for the game and it's our turn,
+//  this has to be queued because we're usually still handling the salvo of our opponent who is waiting for our response
+//  when we still have to place our spaceships the autopilot places them first
+func (xl *XLSpaceship) maybeAutopilot(gameID string) {
+	game, ok := xl.games[gameID]
+	if !ok || xl.autopilotStrategy(gameID) == nil {
+		return
+	}
+
+	if game.Status == ssgame.GameStatusPlacing && !game.SelfReady {
+		xl.autopilotPlace(game)
+		return
+	}
+
+	if game.Status != ssgame.GameStatusOnGoing || game.PlayerTurn != ssgame.PlayerSelf {
+		return
+	}
+
+	go xl.HandleRequest(&autopilotFireRequest{GameID: gameID})
+}
+
+// when our opponent just won the game we ask him to reveal his board, he's supposed to reveal it himself
+//  but when he doesn't we'd never get a verdict, so when this fails too we can't tell if he was honest
+func (xl *XLSpaceship) maybeRevealLost(gameID string) {
+	game, ok := xl.games[gameID]
+	if !ok || game.Status != ssgame.GameStatusDone || game.PlayerWon != ssgame.PlayerOpponent || game.Verdict != ssgame.VerdictPending {
+		return
+	}
+
+	xl.revealAsync(game, func(err error) {
+		if err != nil {
+			revealFailed(game, err)
+		}
+
+		err = xl.saveGame(gameID)
+		if err != nil {
+			fmt.Printf("Failed to save game: %s \n", err)
+		}
+	})
 }
 
 func (xl *XLSpaceship) HandleRequest(req interface{}) *XLResponse {
@@ -137,11 +392,35 @@ func (xl *XLSpaceship) NewGameRequest(req *NewGameRequest) (*NewGameResponse, er
 		return nil, errors.Errorf("Failed to create new game: opponent has same user_id or fullname as player")
 	}
 
-	game, err := ssgame.CreateNewGame(xl.NewGameID(), opponent, xl.cheat)
+	// use the rules the other player proposed, or the default rules if he didn't propose any
+	rules := ssgame.DefaultRules()
+	if req.Rules != nil {
+		var err error
+		rules, err = req.Rules.ToRules()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to create new game")
+		}
+	}
+
+	game, err := ssgame.CreateNewGame(xl.NewGameID(), opponent, rules, xl.placement, xl.newRandomSource(), xl.cheat)
 	if err != nil {
 		return nil, err
 	}
 
+	// players that don't support commitments won't send one
+	err = game.SetOpponentCommitment(req.Commitment)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to create new game")
+	}
+
+	// use the extensions the other player offered that we support too
+	game.NamedKills = hasExtension(req.Extensions, ExtensionNamedKills)
+
+	err = game.RecordCreated()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to create new game")
+	}
+
 	xl.games[game.GameID] = game
 
 	res := NewGameResponseFromGame(xl, game)
@@ -150,10 +429,45 @@ func (xl *XLSpaceship) NewGameRequest(req *NewGameRequest) (*NewGameResponse, er
 
 // send a NewGameRequest to another player
 func (xl *XLSpaceship) InitNewGameRequest(req *InitGameRequest) (string, error) {
+	if req.Practice != "" {
+		dest, err := xl.practiceProtocol(req.Practice)
+		if err != nil {
+			return "", errors.Wrapf(err, "Failed to init new game")
+		}
+
+		req.SpaceshipProtocol = dest
+	}
+
+	rules := xl.rules
+	if req.Rules != nil {
+		var err error
+		rules, err = req.Rules.ToRules()
+		if err != nil {
+			return "", errors.Wrapf(err, "Failed to init new game")
+		}
+	}
+
+	placement, err := xl.placementStrategy(req.Placement)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to init new game")
+	}
+
+	// create our side of the game before we send the request so we can commit to our board,
+	//  we only find out the game ID and who's first when our opponent responds
+	game, err := ssgame.InitNewGame("", nil, rules, placement, xl.newRandomSource(), ssgame.PlayerNone)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to init new game")
+	}
+
 	newGameReq := &NewGameRequest{
 		UserID:            xl.Player.PlayerID,
 		FullName:          xl.Player.FullName,
 		SpaceshipProtocol: SpaceshipProtocol{xl.Player.ProtocolHost, xl.Player.ProtocolPort},
+		Rules:             GameRulesFromRules(rules),
+		Extensions:        SupportedExtensions,
+	}
+	if game.SelfCommitment != nil {
+		newGameReq.Commitment = game.SelfCommitment.Hash
 	}
 
 	newGameRes, err := xl.requester.NewGame(req.SpaceshipProtocol, newGameReq)
@@ -161,19 +475,48 @@ func (xl *XLSpaceship) InitNewGameRequest(req *InitGameRequest) (string, error)
 		return "", errors.Wrapf(err, "Failed to init new game")
 	}
 
-	firstPlayer := ssgame.PlayerSelf
-	if newGameRes.Starting != xl.Player.PlayerID {
-		firstPlayer = ssgame.PlayerOpponent
+	// the other player decides on the rules, when he didn't send any then he doesn't support rules and used the default
+	if newGameRes.Rules == nil {
+		if !reflect.DeepEqual(rules, ssgame.DefaultRules()) {
+			game, err = ssgame.InitNewGame("", nil, ssgame.DefaultRules(), placement, xl.newRandomSource(), ssgame.PlayerNone)
+			if err != nil {
+				return "", errors.Wrapf(err, "Failed to init new game")
+			}
+		}
+	} else {
+		gameRules, err := newGameRes.Rules.ToRules()
+		if err != nil {
+			return "", errors.Wrapf(err, "Failed to init new game")
+		}
+
+		// we already committed to a board for the rules we proposed
+		if !reflect.DeepEqual(rules, gameRules) {
+			return "", errors.Errorf("Failed to init new game: opponent changed the rules")
+		}
 	}
 
-	opponent := &ssgame.Player{
+	err = game.SetOpponentCommitment(newGameRes.Commitment)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to init new game")
+	}
+
+	// the other player tells us which of the extensions we offered are used
+	game.NamedKills = hasExtension(newGameRes.Extensions, ExtensionNamedKills)
+
+	game.GameID = newGameRes.GameID
+	game.Opponent = &ssgame.Player{
 		PlayerID:     newGameRes.UserID,
 		FullName:     newGameRes.FullName,
 		ProtocolHost: req.SpaceshipProtocol.Hostname,
 		ProtocolPort: req.SpaceshipProtocol.Port,
 	}
 
-	game, err := ssgame.InitNewGame(newGameRes.GameID, opponent, firstPlayer)
+	game.PlayerTurn = ssgame.PlayerSelf
+	if newGameRes.Starting != xl.Player.PlayerID {
+		game.PlayerTurn = ssgame.PlayerOpponent
+	}
+
+	err = game.RecordCreated()
 	if err != nil {
 		return "", errors.Wrapf(err, "Failed to init new game")
 	}
@@ -183,14 +526,24 @@ func (xl *XLSpaceship) InitNewGameRequest(req *InitGameRequest) (string, error)
 	return game.GameID, nil
 }
 
-// retrieve the GameStatusResponse for a game
+// retrieve the GameStatusResponse for a game, or for the game as it was after a turn
 func (xl *XLSpaceship) GameStatusRequest(req *GameStatusRequest) (*GameStatusResponse, error) {
 	game, ok := xl.games[req.GameID]
 	if !ok {
 		return nil, nil
 	}
 
-	res := GameStatusResponseFromGame(xl, game)
+	if req.Turn == nil {
+		return GameStatusResponseFromGame(xl, game), nil
+	}
+
+	pastGame, err := game.AtTurn(*req.Turn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get game status for turn %d", *req.Turn)
+	}
+
+	res := GameStatusResponseFromGame(xl, pastGame)
+	res.Turns = game.Turns()
 
 	return res, nil
 }
@@ -206,6 +559,155 @@ func (xl *XLSpaceship) gameStatus(gameID string) (*GameStatusResponse, bool) {
 	return res, true
 }
 
+// place our spaceships on our board for a game that has manual placement
+func (xl *XLSpaceship) PlaceBoardRequest(req *PlaceBoardRequest) (*GameStatusResponse, error) {
+	// check if game exists
+	game, ok := xl.games[req.GameID]
+	if !ok {
+		return nil, errors.Errorf("Game not found")
+	}
+
+	var board *ssgame.SelfBoard
+	var err error
+	if req.Random {
+		var placement ssgame.PlacementStrategy
+		placement, err = xl.placementStrategy(req.Placement)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to place spaceships")
+		}
+
+		board, err = ssgame.NewRandomSelfBoard(game.Rules, game.Rules.Fleet.Patterns(), placement, game.Rand())
+	} else if req.Board != nil {
+		board, err = ssgame.NewSelfBoardFromPattern(game.Rules, req.Board)
+	} else {
+		placements := make([]*ssgame.Placement, len(req.Placements))
+		for i, placement := range req.Placements {
+			if placement == nil {
+				return nil, errors.Errorf("Failed to place spaceships: empty placement")
+			}
+
+			offset, err := ssgame.CoordsFromString(placement.Offset)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Failed to place spaceships")
+			}
+
+			placements[i] = &ssgame.Placement{
+				Name:     placement.Spaceship,
+				Offset:   offset,
+				Rotation: placement.Rotation,
+				Mirror:   placement.Mirror,
+			}
+		}
+
+		board, err = ssgame.NewSelfBoardFromPlacements(game.Rules, placements)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to place spaceships")
+	}
+
+	err = game.PlaceSelfBoard(board)
+	if err != nil {
+		return nil, err
+	}
+
+	// let our opponent know that we're ready
+	//  if this fails he'll still find out when he let's us know he's ready himself
+	res, err := xl.requester.Ready(opponentProtocol(game), readyRequest(game))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to notify opponent")
+	}
+
+	err = applyReadyResponse(game, res)
+	if err != nil {
+		return nil, err
+	}
+
+	return GameStatusResponseFromGame(xl, game), nil
+}
+
+// let the autopilot place our spaceships on a random board, with the placement strategy for every game
+//  our opponent is told we're ready outside of the Run loop, just like the autopilot's salvos are sent (see requestPeer)
+func (xl *XLSpaceship) autopilotPlace(game *ssgame.Game) {
+	board, err := ssgame.NewRandomSelfBoard(game.Rules, game.Rules.Fleet.Patterns(), xl.placement, game.Rand())
+	if err == nil {
+		err = game.PlaceSelfBoard(board)
+	}
+	if err != nil {
+		fmt.Printf("Autopilot failed to place spaceships: %s \n", err)
+		return
+	}
+
+	dest := opponentProtocol(game)
+	req := readyRequest(game)
+
+	xl.requestPeer(func(requester Requester) func() {
+		res, err := requester.Ready(dest, req)
+
+		return func() {
+			if err == nil {
+				err = applyReadyResponse(game, res)
+			}
+			if err != nil {
+				fmt.Printf("Autopilot failed to notify opponent: %s \n", err)
+			}
+
+			// when our opponent was ready already the game has started and it might be our turn
+			xlRes := xl.finishGameRequest(game.GameID, nil, nil)
+			if xlRes.err != nil {
+				fmt.Printf("Failed to save game: %s \n", xlRes.err)
+			}
+		}
+	})
+}
+
+// the request to let our opponent know we placed our spaceships
+func readyRequest(game *ssgame.Game) *ReadyRequest {
+	return &ReadyRequest{GameID: game.GameID, Commitment: game.SelfCommitment.Hash}
+}
+
+// our opponent tells us whether he's ready in response to us being ready, incase we missed him letting us know
+func applyReadyResponse(game *ssgame.Game, res *ReadyResponse) error {
+	if !res.Ready || game.OpponentReady {
+		return nil
+	}
+
+	err := game.SetOpponentCommitment(res.Commitment)
+	if err != nil {
+		return err
+	}
+
+	return game.SetOpponentReady()
+}
+
+// our opponent let us know he has placed his spaceships
+func (xl *XLSpaceship) ReadyRequest(req *ReadyRequest) (*ReadyResponse, error) {
+	// check if game exists
+	game, ok := xl.games[req.GameID]
+	if !ok {
+		return nil, errors.Errorf("Game not found")
+	}
+
+	err := game.SetOpponentCommitment(req.Commitment)
+	if err != nil {
+		return nil, err
+	}
+
+	err = game.SetOpponentReady()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ReadyResponse{
+		GameID: game.GameID,
+		Ready:  game.SelfReady,
+	}
+	if game.SelfCommitment != nil {
+		res.Commitment = game.SelfCommitment.Hash
+	}
+
+	return res, nil
+}
+
 // receive a salvo from another player
 func (xl *XLSpaceship) ReceiveSalvoRequest(req *ReceiveSalvoRequest) (*SalvoResponse, error) {
 	// check if game exists
@@ -234,8 +736,8 @@ func (xl *XLSpaceship) ReceiveSalvoRequest(req *ReceiveSalvoRequest) (*SalvoResp
 // receive a salvo from another player
 func (xl *XLSpaceship) receiveSalvo(game *ssgame.Game, salvo ssgame.CoordsGroup) (*SalvoResponse, bool, error) {
 	// check that we're not cheating
-	if !xl.cheat && len(salvo) > game.OpponentBoard.CountShipsAlive() {
-		return nil, false, errors.Errorf("More shots than ships alive (%d)", game.OpponentBoard.CountShipsAlive())
+	if !xl.cheat && len(salvo) > game.OpponentShots() {
+		return nil, false, errors.Errorf("More shots than allowed by the salvo rule (%d)", game.OpponentShots())
 	}
 
 	// if the game is already done then we create a mock response with misses
@@ -248,20 +750,36 @@ func (xl *XLSpaceship) receiveSalvo(game *ssgame.Game, salvo ssgame.CoordsGroup)
 		return res, true, nil
 	}
 
+	// check if the spaceships have been placed
+	if game.Status == ssgame.GameStatusPlacing {
+		return nil, false, errors.Errorf("Game has not started yet")
+	}
+
 	// check if it's the opponent's turn, otherwise he's not allowed to fire
 	if game.PlayerTurn != ssgame.PlayerOpponent {
 		return nil, false, errors.Errorf("Not your turn")
 	}
 
-	salvoRes := game.SelfBoard.ReceiveSalvo(salvo)
-	game.PlayerTurn = ssgame.PlayerSelf
+	salvoRes, err := game.ReceiveSalvo(salvo)
+	if err != nil {
+		return nil, false, err
+	}
 
-	if game.SelfBoard.AllShipsDead() {
-		game.Status = ssgame.GameStatusDone
-		game.PlayerWon = ssgame.PlayerOpponent
+	res := SalvoResponseFromSalvoResult(salvoRes, xl, game)
+
+	// prove the result of every shot against the commitment to our board
+	res.Proofs = make(map[string]*ShotProof, len(salvo))
+	for _, coords := range salvo {
+		if game.Rules.InBounds(coords) {
+			proof := game.SelfCommitment.Prove(game.Rules, coords)
+			res.Proofs[coords.String()] = &ShotProof{
+				Salt:  proof.Salt,
+				Proof: proof.Proof,
+			}
+		}
 	}
 
-	return SalvoResponseFromSalvoResult(salvoRes, xl, game), false, nil
+	return res, false, nil
 }
 
 // build a SalvoResponse for when a game is already finished
@@ -304,24 +822,69 @@ func (xl *XLSpaceship) FireSalvoRequest(req *FireSalvoRequest) (*SalvoResponse,
 
 // send a salvo to another player
 func (xl *XLSpaceship) fireSalvo(game *ssgame.Game, salvo ssgame.CoordsGroup) (*SalvoResponse, bool, error) {
+	req, res, err := xl.salvoRequest(game, salvo)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the game is already done, res is a mock response with misses
+	if req == nil {
+		return res, true, nil
+	}
+
+	res, err = xl.requester.ReceiveSalvo(opponentProtocol(game), req)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "Failed to fire salvo (req)")
+	}
+
+	res, err = xl.applySalvoResponse(game, salvo, res)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if res.GameWon != nil {
+		// we won, reveal our board and let our opponent reveal his
+		//  if this fails the game is still won, we just can't tell if our opponent was honest
+		err = xl.reveal(game)
+		if err != nil {
+			revealFailed(game, err)
+		}
+	}
+
+	return res, false, nil
+}
+
+// check that we're allowed to fire the salvo and build the request for our opponent,
+//  when the game is already done there's no request and we get a mock response with misses instead
+func (xl *XLSpaceship) salvoRequest(game *ssgame.Game, salvo ssgame.CoordsGroup) (*ReceiveSalvoRequest, *SalvoResponse, error) {
 	// check that we're not cheating
-	if !xl.cheat && len(salvo) > game.SelfBoard.CountShipsAlive() {
-		return nil, false, errors.Errorf("More shots than ships alive (%d)", game.SelfBoard.CountShipsAlive())
+	if !xl.cheat && len(salvo) > game.SelfShots() {
+		return nil, nil, errors.Errorf("More shots than allowed by the salvo rule (%d)", game.SelfShots())
 	}
 
 	// if the game is already done then we create a mock response with misses
 	if game.Status == ssgame.GameStatusDone {
 		res, err := xl.FireSalvoGameFinished(game, salvo)
 		if err != nil {
-			return nil, false, errors.Wrapf(err, "Failed to fire salvo")
+			return nil, nil, errors.Wrapf(err, "Failed to fire salvo")
 		}
 
-		return res, true, nil
+		return nil, res, nil
+	}
+
+	// check if the spaceships have been placed
+	if game.Status == ssgame.GameStatusPlacing {
+		return nil, nil, errors.Errorf("Game has not started yet")
 	}
 
 	// check if it's self's turn, otherwise he's not allowed to fire
 	if game.PlayerTurn != ssgame.PlayerSelf {
-		return nil, false, errors.Errorf("Not your turn")
+		return nil, nil, errors.Errorf("Not your turn")
+	}
+
+	// a salvo we sent earlier is still waiting for our opponent's response
+	if _, ok := xl.firing[game.GameID]; ok {
+		return nil, nil, errors.Errorf("Already firing a salvo")
 	}
 
 	req := &ReceiveSalvoRequest{
@@ -333,40 +896,330 @@ func (xl *XLSpaceship) fireSalvo(game *ssgame.Game, salvo ssgame.CoordsGroup) (*
 		req.Salvo[i] = salvo.String()
 	}
 
-	res, err := xl.requester.ReceiveSalvo(SpaceshipProtocol{
-		Hostname: game.Opponent.ProtocolHost,
-		Port:     game.Opponent.ProtocolPort,
-	}, req)
-	if err != nil {
-		return nil, false, errors.Wrapf(err, "Failed to fire salvo (req)")
-	}
+	return req, nil, nil
+}
 
-	// mark result on our end
+// check the response of our opponent to our salvo and mark the results on our end
+func (xl *XLSpaceship) applySalvoResponse(game *ssgame.Game, salvo ssgame.CoordsGroup, res *SalvoResponse) (*SalvoResponse, error) {
+	// parse the results
 	salvoRes := make([]*ssgame.ShotResult, 0, len(res.Salvo))
 	for coordsStr, shotResStr := range res.Salvo {
 		coords, err := ssgame.CoordsFromString(coordsStr)
 		if err != nil {
-			return nil, false, errors.Wrapf(err, "Failed to fire salvo")
+			return nil, errors.Wrapf(err, "Failed to fire salvo")
 		}
 
-		shotStatus, err := ssgame.ShotStatusFromString(shotResStr)
+		shotRes, err := shotResultFromString(coords, shotResStr)
 		if err != nil {
-			return nil, false, errors.Wrapf(err, "Failed to fire salvo")
+			return nil, errors.Wrapf(err, "Failed to fire salvo")
+		}
+
+		salvoRes = append(salvoRes, shotRes)
+	}
+
+	// the results in the order we fired them, so we can replay them on our opponent's board when he reveals it
+	//  our opponent has to report exactly the coords we fired, so he can't leave out shots or add any
+	firedRes, err := ssgame.OrderSalvoResults(salvo, salvoRes)
+	if err != nil {
+		return nil, abortCheat(game, err)
+	}
+
+	// check the results against the commitment of our opponent before we accept them
+	proofs := make(map[ssgame.Coords]*ssgame.CellProof, len(res.Proofs))
+	for coordsStr, proof := range res.Proofs {
+		coords, err := ssgame.CoordsFromString(coordsStr)
+		if err != nil || proof == nil {
+			continue
 		}
 
-		salvoRes = append(salvoRes, &ssgame.ShotResult{coords, shotStatus})
+		proofs[*coords] = &ssgame.CellProof{
+			Salt:  proof.Salt,
+			Proof: proof.Proof,
+		}
+	}
 
-		game.OpponentBoard.ApplyShotStatus(coords, shotStatus)
+	err = game.VerifySalvoProofs(firedRes, proofs)
+	if err != nil {
+		return nil, abortCheat(game, err)
 	}
 
-	game.PlayerTurn = ssgame.PlayerOpponent
+	// mark the verified results on our end
+	err = game.ApplySalvoResults(salvo, firedRes)
+	if err != nil {
+		return nil, err
+	}
 
 	if res.GameWon != nil {
-		game.Status = ssgame.GameStatusDone
-		game.PlayerWon = ssgame.PlayerSelf
+		err = game.Win(ssgame.PlayerSelf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return SalvoResponseFromSalvoResult(firedRes, xl, game), nil
+}
+
+// our opponent cheated with the results of our salvo, we end the game and he loses
+func abortCheat(game *ssgame.Game, err error) error {
+	abortErr := game.AbortCheat(err.Error())
+	if abortErr != nil {
+		return abortErr
+	}
+
+	return errors.Wrapf(err, "Opponent cheated, game aborted")
+}
+
+// the protocol to reach our opponent in a game on
+func opponentProtocol(game *ssgame.Game) SpaceshipProtocol {
+	return SpaceshipProtocol{
+		Hostname: game.Opponent.ProtocolHost,
+		Port:     game.Opponent.ProtocolPort,
+	}
+}
+
+// send a request to a peer outside of the Run loop, send gets the requester to use and returns a func
+//  that's queued back into the Run loop with the response so it can safely change our games
+//  a peer in our own process (eg. a practice opponent) can be sending us a request while we wait for it,
+//  so waiting for it inside the Run loop would have us both waiting for each other forever
+func (xl *XLSpaceship) requestPeer(send func(requester Requester) func()) {
+	requester := xl.requester
+
+	xl.pending.Add(1)
+	go func() {
+		defer xl.pending.Done()
+
+		done := send(requester)
+		xl.HandleRequest(&peerResponseRequest{done: done})
+	}()
+}
+
+// wait until the responses of the requests we sent to peers are handled, the Run loops of the peers have to keep running for that
+//  so after this nothing is queued anymore (unless we send another request)
+func (xl *XLSpaceship) waitForPeers() {
+	xl.pending.Wait()
+}
+
+// exchange boards with our opponent at the end of the game so we can both verify them against the commitments
+func (xl *XLSpaceship) reveal(game *ssgame.Game) error {
+	// our opponent didn't commit to a board so there's nothing to verify, he probably doesn't support revealing either
+	if game.OpponentCommitment == "" {
+		_, err := game.VerifyOpponentBoard(nil, "")
+		return err
+	}
+
+	res, err := xl.requester.Reveal(opponentProtocol(game), revealRequest(game))
+
+	return verifyReveal(game, res, err)
+}
+
+// the boards couldn't be exchanged at the end of the game, so we can't tell if our opponent was honest
+//  unless he still reveals his board to us later
+func revealFailed(game *ssgame.Game, err error) {
+	fmt.Printf("Failed to reveal board: %s \n", err)
+
+	err = game.RevealFailed(err.Error())
+	if err != nil {
+		fmt.Printf("Failed to mark reveal as failed: %s \n", err)
+	}
+}
+
+// same as reveal, but our opponent's response is handled in the Run loop when it arrives and then done is called
+func (xl *XLSpaceship) revealAsync(game *ssgame.Game, done func(err error)) {
+	if game.OpponentCommitment == "" {
+		done(xl.reveal(game))
+		return
+	}
+
+	dest := opponentProtocol(game)
+	req := revealRequest(game)
+
+	xl.requestPeer(func(requester Requester) func() {
+		res, err := requester.Reveal(dest, req)
+
+		return func() {
+			done(verifyReveal(game, res, err))
+		}
+	})
+}
+
+// the request to reveal our board to our opponent
+func revealRequest(game *ssgame.Game) *RevealRequest {
+	return &RevealRequest{
+		GameID: game.GameID,
+		Board:  game.SelfCommitment.Pattern,
+		Salt:   game.SelfCommitment.Salt,
+	}
+}
+
+// verify the board our opponent revealed in response to ours
+func verifyReveal(game *ssgame.Game, res *RevealResponse, err error) error {
+	if err != nil {
+		return errors.Wrapf(err, "Failed to reveal board")
+	}
+
+	_, err = game.VerifyOpponentBoard(res.Board, res.Salt)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to reveal board")
+	}
+
+	return nil
+}
+
+// our opponent revealed his board at the end of the game, we verify it and reveal ours
+func (xl *XLSpaceship) RevealRequest(req *RevealRequest) (*RevealResponse, error) {
+	// check if game exists
+	game, ok := xl.games[req.GameID]
+	if !ok {
+		return nil, errors.Errorf("Game not found")
+	}
+
+	// we don't reveal anything before the game is done
+	if game.Status != ssgame.GameStatusDone {
+		return nil, errors.Errorf("Game is not done yet")
+	}
+
+	_, err := game.VerifyOpponentBoard(req.Board, req.Salt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to reveal board")
+	}
+
+	return &RevealResponse{
+		GameID: game.GameID,
+		Board:  game.SelfCommitment.Pattern,
+		Salt:   game.SelfCommitment.Salt,
+	}, nil
+}
+
+// suggest a salvo for a game, the salvo only contains cells we haven't fired at yet
+func (xl *XLSpaceship) HintRequest(req *HintRequest) (*HintResponse, error) {
+	// check if game exists
+	game, ok := xl.games[req.GameID]
+	if !ok {
+		return nil, errors.Errorf("Game not found")
+	}
+
+	if game.Status != ssgame.GameStatusOnGoing {
+		return nil, errors.Errorf("Game is not ongoing")
+	}
+
+	strategy, err := ssai.StrategyFromDifficulty(req.Difficulty)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get hint")
+	}
+
+	salvo := strategy.Salvo(game)
+
+	res := &HintResponse{
+		Salvo:    make([]string, len(salvo)),
+		Strategy: strategy.Name(),
+	}
+	for i, coords := range salvo {
+		res.Salvo[i] = coords.String()
+	}
+
+	return res, nil
+}
+
+// turn the autopilot on or off for a game
+func (xl *XLSpaceship) AutopilotRequest(req *AutopilotRequest) (*GameStatusResponse, error) {
+	// check if game exists
+	game, ok := xl.games[req.GameID]
+	if !ok {
+		return nil, errors.Errorf("Game not found")
+	}
+
+	if !req.Enabled {
+		xl.autopilots[game.GameID] = nil
+		return GameStatusResponseFromGame(xl, game), nil
+	}
+
+	strategyName := req.Strategy
+	if strategyName == "" {
+		strategyName = ssai.DefaultStrategyName
 	}
 
-	return SalvoResponseFromSalvoResult(salvoRes, xl, game), false, nil
+	strategy, err := ssai.StrategyFromName(strategyName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to turn on autopilot")
+	}
+
+	xl.autopilots[game.GameID] = strategy
+
+	return GameStatusResponseFromGame(xl, game), nil
+}
+
+// let the autopilot fire a salvo, when it's no longer our turn (or the game is done) there's nothing to do
+//  the salvo is sent outside of the Run loop and resChan gets the response once our opponent answered
+//  (and when we won, once the boards have been revealed)
+func (xl *XLSpaceship) autopilotFire(gameID string, strategy ssai.Strategy, resChan chan *XLResponse) {
+	finish := func(res *SalvoResponse, err error) {
+		if err != nil {
+			fmt.Printf("Autopilot failed to fire salvo: %s \n", err)
+			err = errors.Wrapf(err, "Failed to fire salvo")
+		}
+
+		resChan <- xl.finishGameRequest(gameID, res, err)
+	}
+
+	game, ok := xl.games[gameID]
+	if !ok {
+		finish(nil, errors.Errorf("Game not found"))
+		return
+	}
+
+	if strategy == nil {
+		strategy = xl.autopilotStrategy(gameID)
+	}
+	if strategy == nil || game.Status != ssgame.GameStatusOnGoing || game.PlayerTurn != ssgame.PlayerSelf {
+		finish(nil, nil)
+		return
+	}
+
+	// a salvo we sent earlier is still waiting for our opponent's response, the autopilot continues when it's handled
+	if _, ok := xl.firing[gameID]; ok {
+		resChan <- &XLResponse{nil, nil}
+		return
+	}
+
+	salvo := strategy.Salvo(game)
+	req, res, err := xl.salvoRequest(game, salvo)
+	if err != nil || req == nil {
+		finish(res, err)
+		return
+	}
+
+	// requests for the game wait in firing until our opponent's response is handled
+	dest := opponentProtocol(game)
+	xl.firing[gameID] = nil
+
+	xl.requestPeer(func(requester Requester) func() {
+		res, err := requester.ReceiveSalvo(dest, req)
+
+		return func() {
+			defer xl.doneFiring(gameID)
+
+			if err != nil {
+				finish(nil, errors.Wrapf(err, "Failed to fire salvo (req)"))
+				return
+			}
+
+			res, err := xl.applySalvoResponse(game, salvo, res)
+			if err != nil || res.GameWon == nil {
+				finish(res, err)
+				return
+			}
+
+			// we won, reveal our board and let our opponent reveal his
+			//  if this fails the game is still won, we just can't tell if our opponent was honest
+			xl.revealAsync(game, func(err error) {
+				if err != nil {
+					revealFailed(game, err)
+				}
+
+				finish(res, nil)
+			})
+		}
+	})
 }
 
 // build a SalvoResponse for when a game is already finished
@@ -0,0 +1,94 @@
+package ssclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssai"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSpaceshipAutopilot(t *testing.T) {
+	assert := require.New(t)
+
+	xl1 := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl1)
+	xl2 := NewXLSpaceship("testplayer-2", "Test Player 2", "notlocalhost", 1338)
+	assert.NotNil(xl2)
+
+	xl1.SetRandomSeed(1)
+	xl2.SetRandomSeed(2)
+
+	reqChan1 := make(chan *XLRequest, 1)
+	reqChan2 := make(chan *XLRequest, 1)
+
+	xl1.reqQueue = reqChan1
+	xl2.reqQueue = reqChan2
+	xl1.requester = &MemRequester{reqChan2}
+	xl2.requester = &MemRequester{reqChan1}
+
+	// let the handlers run
+	go func() {
+		xl1.Run()
+	}()
+	go func() {
+		xl2.Run()
+	}()
+
+	// player 1 has the autopilot on for all games
+	xl1.SetAutopilot(&ssai.HuntTargetStrategy{})
+
+	xlRes := xl1.HandleRequest(&InitGameRequest{
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: xl2.Player.ProtocolHost,
+			Port:     xl2.Player.ProtocolPort,
+		},
+	})
+	assert.NoError(xlRes.err)
+	gameID := xlRes.res.(string)
+
+	// unknown games and strategies are rejected
+	xlRes = xl2.HandleRequest(&AutopilotRequest{GameID: "bogus", Enabled: true})
+	assert.Error(xlRes.err)
+	xlRes = xl2.HandleRequest(&AutopilotRequest{GameID: gameID, Enabled: true, Strategy: "bogus"})
+	assert.Error(xlRes.err)
+
+	// player 2 turns the autopilot on for this game, after which the game plays itself
+	xlRes = xl2.HandleRequest(&AutopilotRequest{GameID: gameID, Enabled: true})
+	assert.NoError(xlRes.err)
+	assert.Equal(ssai.DefaultStrategyName, xlRes.res.(*GameStatusResponse).Autopilot)
+
+	gameStatus := func(xl *XLSpaceship) *GameStatusResponse {
+		xlRes := xl.HandleRequest(&GameStatusRequest{GameID: gameID})
+		assert.NoError(xlRes.err)
+		return xlRes.res.(*GameStatusResponse)
+	}
+
+	// the winner only knows it won once it handled the response to it's last salvo, so we wait for both
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		_, done1 := gameStatus(xl1).Game.(GameWonResponse)
+		_, done2 := gameStatus(xl2).Game.(GameWonResponse)
+		if done1 && done2 {
+			break
+		}
+
+		assert.True(time.Now().Before(deadline), "autopilot did not finish the game")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// both players agree on who won and the autopilot stopped firing
+	status1 := gameStatus(xl1)
+	status2 := gameStatus(xl2)
+	assert.Equal(ssai.HuntTargetStrategyName, status1.Autopilot)
+	assert.Equal(status1.Game, status2.Game)
+	assert.Equal(status1.Turns, status2.Turns)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(status1.Turns, gameStatus(xl1).Turns)
+
+	// turning the autopilot off for a game overrides the autopilot for all games
+	xlRes = xl1.HandleRequest(&AutopilotRequest{GameID: gameID, Enabled: false})
+	assert.NoError(xlRes.err)
+	assert.Equal("", xlRes.res.(*GameStatusResponse).Autopilot)
+}
@@ -0,0 +1,110 @@
+package ssclient
+
+import (
+	"testing"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSpaceshipChainOnHit(t *testing.T) {
+	assert := require.New(t)
+
+	xl1 := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl1)
+	xl2 := NewXLSpaceship("testplayer-2", "Test Player 2", "notlocalhost", 1338)
+	assert.NotNil(xl2)
+
+	xl2.EnableCheatMode()
+
+	reqChan1 := make(chan *XLRequest, 1)
+	reqChan2 := make(chan *XLRequest, 1)
+
+	xl1.reqQueue = reqChan1
+	xl2.reqQueue = reqChan2
+	xl1.requester = &MemRequester{reqChan2}
+	xl2.requester = &MemRequester{reqChan1}
+
+	// let the handlers run
+	go func() {
+		xl1.Run()
+	}()
+	go func() {
+		xl2.Run()
+	}()
+
+	rules := ssgame.DefaultRules()
+	rules.ManualPlacement = true
+	rules.ChainOnHit = true
+
+	xlRes := xl1.HandleRequest(&InitGameRequest{
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: xl2.Player.ProtocolHost,
+			Port:     xl2.Player.ProtocolPort,
+		},
+		Rules: GameRulesFromRules(rules),
+	})
+	assert.NoError(xlRes.err)
+	gameID := xlRes.res.(string)
+
+	// both players place their spaceships the same way
+	for _, xl := range []*XLSpaceship{xl1, xl2} {
+		xlRes = xl.HandleRequest(&PlaceBoardRequest{
+			GameID: gameID,
+			Placements: []*SpaceshipPlacement{
+				{Spaceship: "Winger", Offset: "0x0"},
+				{Spaceship: "Angle", Offset: "4x0"},
+				{Spaceship: "A-Class", Offset: "8x0"},
+				{Spaceship: "B-Class", Offset: "0x6"},
+				{Spaceship: "S-Class", Offset: "Bx9", Rotation: 90},
+			},
+		})
+		assert.NoError(xlRes.err)
+		assert.True(xlRes.res.(*GameStatusResponse).Rules.ChainOnHit)
+	}
+
+	assertTurn := func(playerID string) {
+		for _, xl := range []*XLSpaceship{xl1, xl2} {
+			xlRes := xl.HandleRequest(&GameStatusRequest{GameID: gameID})
+			assert.NoError(xlRes.err)
+			assert.Equal(GamePlayerTurnResponse{PlayerTurn: playerID}, xlRes.res.(*GameStatusResponse).Game)
+		}
+	}
+
+	// player 2 cheated to be first
+	assertTurn(xl2.Player.PlayerID)
+
+	// a salvo with a hit keeps the turn
+	xlRes = xl2.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  []string{"0x0", "1x0"},
+	})
+	assert.NoError(xlRes.err)
+	assert.Equal(xl2.Player.PlayerID, xlRes.res.(*SalvoResponse).Game["player_turn"])
+	assertTurn(xl2.Player.PlayerID)
+
+	// a salvo with only misses hands over the turn
+	xlRes = xl2.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  []string{"1x0", "3x0"},
+	})
+	assert.NoError(xlRes.err)
+	assert.Equal(xl1.Player.PlayerID, xlRes.res.(*SalvoResponse).Game["player_turn"])
+	assertTurn(xl1.Player.PlayerID)
+
+	// a kill keeps the turn as well
+	xlRes = xl1.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  []string{"4x0", "4x1", "4x2", "4x3", "5x3"},
+	})
+	assert.NoError(xlRes.err)
+	assertTurn(xl1.Player.PlayerID)
+
+	xlRes = xl1.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  []string{"6x3"},
+	})
+	assert.NoError(xlRes.err)
+	assert.Equal(map[string]string{"6x3": "kill:Angle"}, xlRes.res.(*SalvoResponse).Salvo)
+	assertTurn(xl1.Player.PlayerID)
+}
@@ -0,0 +1,96 @@
+package ssclient
+
+import (
+	"math/rand"
+	"testing"
+
+	"fmt"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSpaceshipCheat(t *testing.T) {
+	assert := require.New(t)
+
+	xl1 := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl1)
+	xl2 := NewXLSpaceship("testplayer-2", "Test Player 2", "notlocalhost", 1338)
+	assert.NotNil(xl2)
+
+	xl1.SetRandomSeed(1)
+	xl1.EnableCheatMode()
+	xl2.EnableCheatMode()
+
+	reqChan1 := make(chan *XLRequest, 1)
+	reqChan2 := make(chan *XLRequest, 1)
+
+	xl1.reqQueue = reqChan1
+	xl2.reqQueue = reqChan2
+	xl1.requester = &MemRequester{reqChan2}
+	xl2.requester = &MemRequester{reqChan1}
+
+	// let the handlers run
+	go func() {
+		xl1.Run()
+	}()
+	go func() {
+		xl2.Run()
+	}()
+
+	xlRes := xl1.HandleRequest(&InitGameRequest{
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: xl2.Player.ProtocolHost,
+			Port:     xl2.Player.ProtocolPort,
+		},
+	})
+	assert.NoError(xlRes.err)
+	gameID := xlRes.res.(string)
+
+	// both players committed to their board
+	game1 := xl1.games[gameID]
+	game2 := xl2.games[gameID]
+	assert.Equal(game1.SelfCommitment.Hash, game2.OpponentCommitment)
+	assert.Equal(game2.SelfCommitment.Hash, game1.OpponentCommitment)
+
+	// player 1 moves his spaceships after he committed to his board
+	rules := ssgame.DefaultRules()
+	board, err := ssgame.NewRandomSelfBoard(rules, rules.Fleet.Patterns(), nil, rand.New(ssgame.NewSeededRandomSource(1337)))
+	assert.NoError(err)
+	assert.NotEqual(game1.SelfBoard.ToPattern(), board.ToPattern())
+	game1.SelfBoard = board
+
+	// player 2 cheated to be first and fires at every cell
+	salvo := make([]string, 0, 16*16)
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 16; y++ {
+			salvo = append(salvo, fmt.Sprintf("%Xx%X", x, y))
+		}
+	}
+	xlRes = xl2.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  salvo,
+	})
+
+	// player 2 immediately finds out player 1 lied about the results, because they don't match his proofs
+	assert.Error(xlRes.err)
+	assert.Contains(xlRes.err.Error(), "Opponent cheated, game aborted")
+
+	xlRes = xl2.HandleRequest(&GameStatusRequest{GameID: gameID})
+	assert.NoError(xlRes.err)
+	status := xlRes.res.(*GameStatusResponse)
+	assert.Equal(GameWonResponse{Won: xl2.Player.PlayerID}, status.Game)
+	assert.Equal("cheat", status.Verdict)
+	assert.Contains(status.Cheat, "but it's")
+
+	// revealing is only allowed when the game is done
+	xlRes = xl1.HandleRequest(&InitGameRequest{
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: xl2.Player.ProtocolHost,
+			Port:     xl2.Player.ProtocolPort,
+		},
+	})
+	assert.NoError(xlRes.err)
+	xlRes = xl2.HandleRequest(&RevealRequest{GameID: xlRes.res.(string)})
+	assert.Error(xlRes.err)
+}
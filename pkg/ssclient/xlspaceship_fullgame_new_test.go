@@ -1,9 +1,11 @@
 package ssclient
 
 import (
+	"encoding/json"
 	"testing"
 
 	"fmt"
+	"strings"
 
 	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
 	"github.com/stretchr/testify/require"
@@ -45,7 +47,7 @@ func TestXLSpaceshipFullGameNew(t *testing.T) {
 		// player 1
 		func(xl *XLSpaceship) {
 			_, err := xl.InitNewGameRequest(&InitGameRequest{
-				SpaceshipProtocol{
+				SpaceshipProtocol: SpaceshipProtocol{
 					Hostname: xl2.Player.ProtocolHost,
 					Port:     xl2.Player.ProtocolPort,
 				},
@@ -132,4 +134,51 @@ func TestXLSpaceshipFullGameNew(t *testing.T) {
 
 		xl1Turn = !xl1Turn
 	}
+
+	// both players revealed their boards and were honest
+	assert.Equal(ssgame.VerdictHonest, xl1.games[gameID].Verdict)
+	assert.Equal(ssgame.VerdictHonest, xl2.games[gameID].Verdict)
+
+	// the status of the game after every turn, the first turn was the salvo of player 1
+	numTurns := xl1.games[gameID].Turns()
+	assert.True(numTurns > 0)
+	for turn := 0; turn <= numTurns; turn++ {
+		xlRes := xl1.HandleRequest(&GameStatusRequest{GameID: gameID, Turn: &turn})
+		assert.NoError(xlRes.err)
+		status := xlRes.res.(*GameStatusResponse)
+		assert.Equal(turn, status.Turn)
+		assert.Equal(numTurns, status.Turns)
+
+		if turn == 0 {
+			assert.Equal(xl1.games[gameID].OpponentBoard.Rules().Height, len(status.Opponent.Board))
+			assert.Equal(0, strings.Count(strings.Join(status.Opponent.Board, ""), "X"))
+		}
+	}
+
+	xlRes := xl1.HandleRequest(&GameStatusRequest{GameID: gameID})
+	assert.NoError(xlRes.err)
+	current := xlRes.res.(*GameStatusResponse)
+	xlRes = xl1.HandleRequest(&GameStatusRequest{GameID: gameID, Turn: &numTurns})
+	assert.NoError(xlRes.err)
+	assert.Equal(current, xlRes.res.(*GameStatusResponse))
+
+	tooFar := numTurns + 1
+	xlRes = xl1.HandleRequest(&GameStatusRequest{GameID: gameID, Turn: &tooFar})
+	assert.Error(xlRes.err)
+
+	// replaying the log of the game gives the same game for both players
+	for _, xl := range []*XLSpaceship{xl1, xl2} {
+		game := xl.games[gameID]
+		assert.Equal(ssgame.EventGameCreated, game.Events[0].Type)
+		assert.Equal(ssgame.EventBoardsRevealed, game.Events[len(game.Events)-1].Type)
+
+		replayed, err := ssgame.Replay(game.Events)
+		assert.NoError(err)
+
+		expected, err := json.Marshal(game)
+		assert.NoError(err)
+		actual, err := json.Marshal(replayed)
+		assert.NoError(err)
+		assert.Equal(string(expected), string(actual))
+	}
 }
@@ -85,7 +85,7 @@ func TestXLSpaceshipFullGameOld(t *testing.T) {
 		<-xl1GoChan
 
 		_, err := xl.InitNewGameRequest(&InitGameRequest{
-			SpaceshipProtocol{
+			SpaceshipProtocol: SpaceshipProtocol{
 				Hostname: xl2.Player.ProtocolHost,
 				Port:     xl2.Player.ProtocolPort,
 			},
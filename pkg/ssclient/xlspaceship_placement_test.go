@@ -0,0 +1,143 @@
+package ssclient
+
+import (
+	"testing"
+
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSpaceshipManualPlacement(t *testing.T) {
+	assert := require.New(t)
+
+	xl1 := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl1)
+	xl2 := NewXLSpaceship("testplayer-2", "Test Player 2", "notlocalhost", 1338)
+	assert.NotNil(xl2)
+
+	xl2.EnableCheatMode()
+
+	reqChan1 := make(chan *XLRequest, 1)
+	reqChan2 := make(chan *XLRequest, 1)
+
+	xl1.reqQueue = reqChan1
+	xl2.reqQueue = reqChan2
+	xl1.requester = &MemRequester{reqChan2}
+	xl2.requester = &MemRequester{reqChan1}
+
+	// let the handlers run
+	go func() {
+		xl1.Run()
+	}()
+	go func() {
+		xl2.Run()
+	}()
+
+	rules := ssgame.DefaultRules()
+	rules.ManualPlacement = true
+
+	xlRes := xl1.HandleRequest(&InitGameRequest{
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: xl2.Player.ProtocolHost,
+			Port:     xl2.Player.ProtocolPort,
+		},
+		Rules: GameRulesFromRules(rules),
+	})
+	assert.NoError(xlRes.err)
+	gameID := xlRes.res.(string)
+
+	// both players should be placing
+	for _, xl := range []*XLSpaceship{xl1, xl2} {
+		xlRes = xl.HandleRequest(&GameStatusRequest{GameID: gameID})
+		assert.NoError(xlRes.err)
+		status := xlRes.res.(*GameStatusResponse)
+		assert.Equal(GamePlacingResponse{Placing: true}, status.Game)
+		assert.True(status.Rules.ManualPlacement)
+	}
+
+	// firing is not allowed while placing
+	xlRes = xl2.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  []string{"0x0"},
+	})
+	assert.Error(xlRes.err)
+
+	// invalid placement should be rejected
+	xlRes = xl1.HandleRequest(&PlaceBoardRequest{
+		GameID: gameID,
+		Placements: []*SpaceshipPlacement{
+			{Spaceship: "Winger", Offset: "0x0"},
+		},
+	})
+	assert.Error(xlRes.err)
+
+	// player 1 places his spaceships
+	xlRes = xl1.HandleRequest(&PlaceBoardRequest{
+		GameID: gameID,
+		Placements: []*SpaceshipPlacement{
+			{Spaceship: "Winger", Offset: "0x0"},
+			{Spaceship: "Angle", Offset: "4x0"},
+			{Spaceship: "A-Class", Offset: "8x0"},
+			{Spaceship: "B-Class", Offset: "0x6"},
+			{Spaceship: "S-Class", Offset: "Bx9", Rotation: 90},
+		},
+	})
+	assert.NoError(xlRes.err)
+	status := xlRes.res.(*GameStatusResponse)
+	assert.Equal(GamePlacingResponse{Placing: true, SelfReady: true}, status.Game)
+	assert.Equal("*.*.*....*......", status.Self.Board[0])
+
+	// player 2 knows player 1 is ready
+	xlRes = xl2.HandleRequest(&GameStatusRequest{GameID: gameID})
+	assert.NoError(xlRes.err)
+	assert.Equal(GamePlacingResponse{Placing: true, OpponentReady: true}, xlRes.res.(*GameStatusResponse).Game)
+
+	// player 2 draws his board
+	xlRes = xl2.HandleRequest(&PlaceBoardRequest{
+		GameID: gameID,
+		Board: []string{
+			"*.*.*....*......",
+			"*.*.*...*.*.....",
+			".*..*...***.....",
+			"*.*.***.*.*.....",
+			"*.*.............",
+			"................",
+			"**..............",
+			"*.*.............",
+			"**..............",
+			"*.*...........*.",
+			"**.........*.*.*",
+			"...........*.*.*",
+			"............*...",
+			"................",
+			"................",
+			"................",
+		},
+	})
+	assert.NoError(xlRes.err)
+
+	// placing twice is not allowed
+	xlRes = xl2.HandleRequest(&PlaceBoardRequest{
+		GameID: gameID,
+		Random: true,
+	})
+	assert.Error(xlRes.err)
+
+	// both players should have started, player 2 cheated to be first
+	for _, xl := range []*XLSpaceship{xl1, xl2} {
+		xlRes = xl.HandleRequest(&GameStatusRequest{GameID: gameID})
+		assert.NoError(xlRes.err)
+		status := xlRes.res.(*GameStatusResponse)
+		assert.Equal(GamePlayerTurnResponse{PlayerTurn: xl2.Player.PlayerID}, status.Game)
+	}
+
+	xlRes = xl2.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  []string{"0x0", "1x0"},
+	})
+	assert.NoError(xlRes.err)
+	assert.Equal(map[string]string{
+		"0x0": "hit",
+		"1x0": "miss",
+	}, xlRes.res.(*SalvoResponse).Salvo)
+}
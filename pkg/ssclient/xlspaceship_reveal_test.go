@@ -0,0 +1,113 @@
+package ssclient
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/require"
+)
+
+// Requester that doesn't pass on reveals, like a player that doesn't reveal his board at the end of the game
+type noRevealRequester struct {
+	*MemRequester
+}
+
+func (r *noRevealRequester) Reveal(dest SpaceshipProtocol, req *RevealRequest) (*RevealResponse, error) {
+	return nil, errors.New("Failed to request reveal: not supported")
+}
+
+// play a game where player 1 wins with a salvo on every cell, player 2 fires a single shot first when it's his turn
+//  both cheat so the salvo rule doesn't get in the way, and return the verdict of both players once player 2 has one
+func playRevealGame(t *testing.T, revealWinner bool, revealLoser bool) (ssgame.Verdict, ssgame.Verdict) {
+	assert := require.New(t)
+
+	xl1 := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	xl2 := NewXLSpaceship("testplayer-2", "Test Player 2", "notlocalhost", 1338)
+	xl1.SetRandomSeed(1)
+	xl2.SetRandomSeed(2)
+	xl1.EnableCheatMode()
+	xl2.EnableCheatMode()
+
+	reqChan1 := make(chan *XLRequest, 1)
+	reqChan2 := make(chan *XLRequest, 1)
+
+	xl1.reqQueue = reqChan1
+	xl2.reqQueue = reqChan2
+	xl1.requester = &MemRequester{reqChan2}
+	xl2.requester = &MemRequester{reqChan1}
+	if !revealWinner {
+		xl1.requester = &noRevealRequester{&MemRequester{reqChan2}}
+	}
+	if !revealLoser {
+		xl2.requester = &noRevealRequester{&MemRequester{reqChan1}}
+	}
+
+	// let the handlers run
+	go func() {
+		xl1.Run()
+	}()
+	go func() {
+		xl2.Run()
+	}()
+
+	xlRes := xl1.HandleRequest(&InitGameRequest{
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: xl2.Player.ProtocolHost,
+			Port:     xl2.Player.ProtocolPort,
+		},
+	})
+	assert.NoError(xlRes.err)
+	gameID := xlRes.res.(string)
+
+	gameStatus := func(xl *XLSpaceship) *GameStatusResponse {
+		xlRes := xl.HandleRequest(&GameStatusRequest{GameID: gameID})
+		assert.NoError(xlRes.err)
+		return xlRes.res.(*GameStatusResponse)
+	}
+
+	if gameStatus(xl1).Game.(GamePlayerTurnResponse).PlayerTurn != xl1.Player.PlayerID {
+		xlRes = xl2.HandleRequest(&FireSalvoRequest{GameID: gameID, Salvo: []string{"0x0"}})
+		assert.NoError(xlRes.err)
+	}
+
+	salvo := make([]string, 0, 16*16)
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 16; y++ {
+			salvo = append(salvo, fmt.Sprintf("%Xx%X", x, y))
+		}
+	}
+	xlRes = xl1.HandleRequest(&FireSalvoRequest{GameID: gameID, Salvo: salvo})
+	assert.NoError(xlRes.err)
+	assert.Equal(xl1.Player.PlayerID, xlRes.res.(*SalvoResponse).GameWon.Won)
+
+	// player 2 asks for the reveal himself after he lost, that's handled after he answered the last salvo
+	deadline := time.Now().Add(10 * time.Second)
+	for gameStatus(xl2).Verdict == ssgame.VerdictPending.String() {
+		assert.True(time.Now().Before(deadline), "player 2 got no verdict")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return xl1.games[gameID].Verdict, xl2.games[gameID].Verdict
+}
+
+func TestXLSpaceshipRevealLoser(t *testing.T) {
+	assert := require.New(t)
+
+	// both reveal, so both players get a verdict right away
+	verdict1, verdict2 := playRevealGame(t, true, true)
+	assert.Equal(ssgame.VerdictHonest, verdict1)
+	assert.Equal(ssgame.VerdictHonest, verdict2)
+
+	// the winner doesn't reveal, the loser asks for it and both boards are revealed after all
+	verdict1, verdict2 = playRevealGame(t, false, true)
+	assert.Equal(ssgame.VerdictHonest, verdict1)
+	assert.Equal(ssgame.VerdictHonest, verdict2)
+
+	// nobody reveals, so nobody can tell if the other was honest
+	verdict1, verdict2 = playRevealGame(t, false, false)
+	assert.Equal(ssgame.VerdictUnverifiable, verdict1)
+	assert.Equal(ssgame.VerdictUnverifiable, verdict2)
+}
@@ -0,0 +1,112 @@
+package ssclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestXLSpaceshipRestart(t *testing.T) {
+	assert := require.New(t)
+
+	store1 := NewMemGameStore()
+	xl1, err := NewXLSpaceshipWithStore("testplayer-1", "Test Player 1", "notlocalhost", 1337, store1)
+	assert.NoError(err)
+	xl2 := NewXLSpaceship("testplayer-2", "Test Player 2", "notlocalhost", 1338)
+
+	// player 1 is first and can fire as much as he wants
+	xl1.EnableCheatMode()
+
+	reqChan1 := make(chan *XLRequest, 1)
+	reqChan2 := make(chan *XLRequest, 1)
+
+	xl1.reqQueue = reqChan1
+	xl2.reqQueue = reqChan2
+	xl1.requester = &MemRequester{reqChan2}
+	xl2.requester = &MemRequester{reqChan1}
+
+	// let the handlers run
+	go func() {
+		xl1.Run()
+	}()
+	go func() {
+		xl2.Run()
+	}()
+
+	// player 2 starts the game, so the game ID is one of player 1's
+	xlRes := xl2.HandleRequest(&InitGameRequest{
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: xl1.Player.ProtocolHost,
+			Port:     xl1.Player.ProtocolPort,
+		},
+	})
+	assert.NoError(xlRes.err)
+	gameID := xlRes.res.(string)
+	assert.Equal("match-testplayer-1-1", gameID)
+
+	xlRes = xl1.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  []string{"0x0", "1x1", "2x2"},
+	})
+	assert.NoError(xlRes.err)
+
+	xlRes = xl1.HandleRequest(&GameStatusRequest{GameID: gameID})
+	assert.NoError(xlRes.err)
+	status := xlRes.res.(*GameStatusResponse)
+
+	// restart player 1, player 1 stops handling requests and a new player 1 takes over with the same store
+	close(reqChan1)
+	xl1, err = NewXLSpaceshipWithStore("testplayer-1", "Test Player 1", "notlocalhost", 1337, store1)
+	assert.NoError(err)
+	xl1.EnableCheatMode()
+
+	reqChan1 = make(chan *XLRequest, 1)
+	xl1.reqQueue = reqChan1
+	xl1.requester = &MemRequester{reqChan2}
+	xl2.requester = &MemRequester{reqChan1}
+
+	go func() {
+		xl1.Run()
+	}()
+
+	// the game is exactly as we left it
+	xlRes = xl1.HandleRequest(&GameStatusRequest{GameID: gameID})
+	assert.NoError(xlRes.err)
+	assert.Equal(status, xlRes.res.(*GameStatusResponse))
+
+	// and we can continue playing it
+	xlRes = xl2.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  []string{"0x0"},
+	})
+	assert.NoError(xlRes.err)
+
+	xlRes = xl1.HandleRequest(&FireSalvoRequest{
+		GameID: gameID,
+		Salvo:  []string{"3x3"},
+	})
+	assert.NoError(xlRes.err)
+
+	// all our shots should be on the board of player 2
+	xlRes = xl2.HandleRequest(&GameStatusRequest{GameID: gameID})
+	assert.NoError(xlRes.err)
+	board := xlRes.res.(*GameStatusResponse).Self.Board
+	for i := 0; i < 4; i++ {
+		assert.Contains("X-", string(board[i][i]))
+	}
+
+	// and the shot of player 2 on the board of player 1
+	xlRes = xl1.HandleRequest(&GameStatusRequest{GameID: gameID})
+	assert.NoError(xlRes.err)
+	assert.Contains("X-", string(xlRes.res.(*GameStatusResponse).Self.Board[0][0]))
+
+	// a new game doesn't reuse the game ID
+	xlRes = xl2.HandleRequest(&InitGameRequest{
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: xl1.Player.ProtocolHost,
+			Port:     xl1.Player.ProtocolPort,
+		},
+	})
+	assert.NoError(xlRes.err)
+	assert.Equal("match-testplayer-1-2", xlRes.res.(string))
+}
@@ -3,7 +3,10 @@ package ssclient
 import (
 	"testing"
 
+	"reflect"
+
 	"github.com/rubensayshi/xlspaceship/pkg/ssgame"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,6 +52,235 @@ func TestXLSpaceship_NewGame(t *testing.T) {
 	assert.Equal("Test Player 1", res.FullName)
 }
 
+func TestXLSpaceship_NewGameWithRules(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+
+	req := &NewGameRequest{
+		UserID:   "testplayer-2",
+		FullName: "Test Player 2",
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: "notlocalhost2",
+			Port:     6666,
+		},
+		Rules: &GameRules{
+			Width:   10,
+			Height:  12,
+			NoTouch: true,
+		},
+	}
+
+	res, err := xl.NewGameRequest(req)
+	assert.NoError(err)
+	assert.NotNil(res)
+	assert.Equal(10, res.Rules.Width)
+	assert.Equal(12, res.Rules.Height)
+	assert.True(res.Rules.NoTouch)
+	// no fleet means the fleet for a standard game
+	assert.Equal(GameRulesFromRules(ssgame.DefaultRules()).Fleet, res.Rules.Fleet)
+
+	game := xl.games[res.GameID]
+	assert.True(game.Rules.NoTouch)
+	assert.Equal(12, len(game.SelfBoard.ToPattern()))
+	assert.Equal(10, len(game.SelfBoard.ToPattern()[0]))
+	assert.Equal(12, len(game.OpponentBoard.ToPattern()))
+	assert.Equal(10, len(game.OpponentBoard.ToPattern()[0]))
+}
+
+func TestXLSpaceship_NewGameWithFleet(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+
+	req := &NewGameRequest{
+		UserID:   "testplayer-2",
+		FullName: "Test Player 2",
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: "notlocalhost2",
+			Port:     6666,
+		},
+		Rules: &GameRules{
+			Width:  16,
+			Height: 16,
+			Fleet: []*GameFleetSpaceship{
+				{Name: "Pumpkin", Pattern: []string{".*.", "***"}, Count: 3},
+			},
+		},
+	}
+
+	res, err := xl.NewGameRequest(req)
+	assert.NoError(err)
+	assert.NotNil(res)
+	assert.Equal(req.Rules.Fleet, res.Rules.Fleet)
+
+	game := xl.games[res.GameID]
+	assert.Equal(3, len(game.SelfBoard.Spaceships()))
+	assert.Equal(3, game.SelfBoard.CountShipsAlive())
+	assert.Equal(3, game.OpponentBoard.CountShipsAlive())
+}
+
+func TestXLSpaceship_NewGameInvalidFleet(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+
+	req := &NewGameRequest{
+		UserID:   "testplayer-2",
+		FullName: "Test Player 2",
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: "notlocalhost2",
+			Port:     6666,
+		},
+		Rules: &GameRules{
+			Width:  16,
+			Height: 16,
+			Fleet: []*GameFleetSpaceship{
+				{Name: "Pumpkin", Pattern: []string{".A.", "***"}, Count: 1},
+			},
+		},
+	}
+
+	_, err := xl.NewGameRequest(req)
+	assert.Error(err)
+}
+
+func TestXLSpaceship_NewGameInvalidRules(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+
+	req := &NewGameRequest{
+		UserID:   "testplayer-2",
+		FullName: "Test Player 2",
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: "notlocalhost2",
+			Port:     6666,
+		},
+		Rules: &GameRules{
+			Width:  64,
+			Height: 16,
+		},
+	}
+
+	_, err := xl.NewGameRequest(req)
+	assert.Error(err)
+}
+
+func TestXLSpaceship_SalvoRule(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+
+	mockRequester := &MockRequester{}
+	xl.requester = mockRequester
+
+	ssProtocol := SpaceshipProtocol{
+		Hostname: "notlocalhost2",
+		Port:     6666,
+	}
+
+	res, err := xl.NewGameRequest(&NewGameRequest{
+		UserID:            "testplayer-2",
+		SpaceshipProtocol: ssProtocol,
+		Rules: &GameRules{
+			Width:      16,
+			Height:     16,
+			Salvo:      ssgame.SalvoRuleFixedName,
+			SalvoShots: 2,
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(ssgame.SalvoRuleFixedName, res.Rules.Salvo)
+	assert.Equal(2, res.Rules.SalvoShots)
+
+	game := xl.games[res.GameID]
+	assert.Equal(&ssgame.FixedSalvoRule{N: 2}, game.Rules.Salvo)
+
+	status, ok := xl.gameStatus(game.GameID)
+	assert.True(ok)
+	assert.Equal(2, status.Self.Shots)
+	assert.Equal(2, status.Opponent.Shots)
+
+	// our opponent is not allowed more shots than the rule allows
+	_, _, err = xl.receiveSalvo(game, ssgame.CoordsGroup{
+		mustCoordsFromString("0x0"),
+		mustCoordsFromString("1x0"),
+		mustCoordsFromString("2x0"),
+	})
+	assert.Error(err)
+
+	// and neither are we
+	game.PlayerTurn = ssgame.PlayerSelf
+	_, _, err = xl.fireSalvo(game, ssgame.CoordsGroup{
+		mustCoordsFromString("0x0"),
+		mustCoordsFromString("1x0"),
+		mustCoordsFromString("2x0"),
+	})
+	assert.Error(err)
+
+	mockRequester.AssertExpectations(t)
+}
+
+func TestXLSpaceship_NewGameInvalidSalvoRule(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+
+	for _, rules := range []*GameRules{
+		{Width: 16, Height: 16, Salvo: "all-you-can-shoot"},
+		{Width: 16, Height: 16, Salvo: ssgame.SalvoRuleFixedName},
+	} {
+		_, err := xl.NewGameRequest(&NewGameRequest{
+			UserID: "testplayer-2",
+			SpaceshipProtocol: SpaceshipProtocol{
+				Hostname: "notlocalhost2",
+				Port:     6666,
+			},
+			Rules: rules,
+		})
+		assert.Error(err)
+	}
+}
+
+func TestXLSpaceship_SetRandomSeed(t *testing.T) {
+	assert := require.New(t)
+
+	req := &NewGameRequest{
+		UserID:   "testplayer-2",
+		FullName: "Test Player 2",
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: "notlocalhost2",
+			Port:     6666,
+		},
+	}
+
+	// with the same seed we should get the same boards
+	boards := make([][][]string, 2)
+	for i := range boards {
+		xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+		xl.SetRandomSeed(1337)
+
+		for j := 0; j < 2; j++ {
+			res, err := xl.NewGameRequest(req)
+			assert.NoError(err)
+
+			boards[i] = append(boards[i], xl.games[res.GameID].SelfBoard.ToPattern())
+		}
+	}
+
+	assert.Equal(boards[0], boards[1])
+
+	// but every game should get a different board
+	assert.NotEqual(boards[0][0], boards[0][1])
+}
+
 func TestXLSpaceship_NewGameSameUserID(t *testing.T) {
 	assert := require.New(t)
 
@@ -89,7 +321,7 @@ func TestXLSpaceship_ReceiveSalvo(t *testing.T) {
 
 	game := xl.games[res.GameID]
 
-	selfBoard, err := ssgame.NewBlankSelfBoard()
+	selfBoard, err := ssgame.NewBlankSelfBoard(ssgame.DefaultRules())
 	assert.NoError(err)
 
 	spaceship, err := ssgame.SpaceshipFromPattern([]string{"***"})
@@ -100,6 +332,9 @@ func TestXLSpaceship_ReceiveSalvo(t *testing.T) {
 	// swap out the created board with our test board
 	game.SelfBoard = selfBoard
 
+	// make it our opponent's turn
+	game.PlayerTurn = ssgame.PlayerOpponent
+
 	salvo, err := ssgame.CoordsGroupFromSalvoStrings([]string{"0x0", "1x0", "2x0"})
 	assert.NoError(err)
 
@@ -117,6 +352,69 @@ func TestXLSpaceship_ReceiveSalvo(t *testing.T) {
 	assert.Equal("testplayer-2", salvoRes.GameWon.Won)
 }
 
+func TestXLSpaceship_ReceiveSalvoNamedKills(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+	xl.EnableCheatMode()
+
+	newGame := func(extensions []string) *ssgame.Game {
+		res, err := xl.NewGameRequest(&NewGameRequest{
+			UserID:   "testplayer-2",
+			FullName: "Test Player 2",
+			SpaceshipProtocol: SpaceshipProtocol{
+				Hostname: "notlocalhost2",
+				Port:     6666,
+			},
+			Extensions: extensions,
+		})
+		assert.NoError(err)
+
+		game := xl.games[res.GameID]
+		assert.Equal(gameExtensions(game), res.Extensions)
+
+		// make it our opponent's turn
+		game.PlayerTurn = ssgame.PlayerOpponent
+
+		return game
+	}
+
+	// an opponent that doesn't support named kills gets a plain kill
+	game := newGame(nil)
+	assert.False(game.NamedKills)
+
+	killed := game.SelfBoard.Spaceships()[0]
+	salvoRes, _, err := xl.receiveSalvo(game, killed.Coords())
+	assert.NoError(err)
+	assert.Equal(ssgame.ShotStatusKillStr, salvoRes.Salvo[killed.Coords()[len(killed.Coords())-1].String()])
+
+	// only the extensions we support are used
+	game = newGame([]string{"bogus", ExtensionNamedKills})
+	assert.True(game.NamedKills)
+	assert.Equal([]string{ExtensionNamedKills}, gameExtensions(game))
+
+	killed = game.SelfBoard.Spaceships()[0]
+	salvoRes, _, err = xl.receiveSalvo(game, killed.Coords())
+	assert.NoError(err)
+	assert.Equal(ssgame.ShotStatusKillStr+":"+killed.Name(), salvoRes.Salvo[killed.Coords()[len(killed.Coords())-1].String()])
+	assert.Equal(ssgame.BaseGameFleet()[0].Name, killed.Name())
+
+	// the name of the spaceship we killed is parsed from the result
+	shotRes, err := shotResultFromString(killed.Coords()[0], "kill:Winger")
+	assert.NoError(err)
+	assert.Equal(ssgame.ShotStatusKill, shotRes.ShotStatus)
+	assert.Equal("Winger", shotRes.Spaceship)
+
+	shotRes, err = shotResultFromString(killed.Coords()[0], "hit")
+	assert.NoError(err)
+	assert.Equal(ssgame.ShotStatusHit, shotRes.ShotStatus)
+	assert.Equal("", shotRes.Spaceship)
+
+	_, err = shotResultFromString(killed.Coords()[0], "hit:Winger")
+	assert.Error(err)
+}
+
 func TestXLSpaceship_ReceiveSalvoGameFinished(t *testing.T) {
 	assert := require.New(t)
 
@@ -138,7 +436,7 @@ func TestXLSpaceship_ReceiveSalvoGameFinished(t *testing.T) {
 
 	game := xl.games[res.GameID]
 
-	selfBoard, err := ssgame.NewBlankSelfBoard()
+	selfBoard, err := ssgame.NewBlankSelfBoard(ssgame.DefaultRules())
 	assert.NoError(err)
 
 	spaceship, err := ssgame.SpaceshipFromPattern([]string{"***"})
@@ -189,18 +487,63 @@ func TestXLSpaceship_InitNewGame(t *testing.T) {
 		SpaceshipProtocol: ssProtocol,
 	}
 
-	mockRequester.On("NewGame", ssProtocol, NewGameRequest{
-		UserID:   "testplayer-1",
-		FullName: "Test Player 1",
-		SpaceshipProtocol: SpaceshipProtocol{
-			Hostname: "notlocalhost",
-			Port:     1337,
-		},
-	}).Return(&NewGameResponse{}, nil)
+	// the commitment is a salted hash so we can't know it up front
+	mockRequester.On("NewGame", ssProtocol, mock.MatchedBy(func(newGameReq NewGameRequest) bool {
+		commitment := newGameReq.Commitment
+		newGameReq.Commitment = ""
+
+		return len(commitment) == 64 && reflect.DeepEqual(NewGameRequest{
+			UserID:   "testplayer-1",
+			FullName: "Test Player 1",
+			SpaceshipProtocol: SpaceshipProtocol{
+				Hostname: "notlocalhost",
+				Port:     1337,
+			},
+			Rules:      GameRulesFromRules(ssgame.DefaultRules()),
+			Extensions: SupportedExtensions,
+		}, newGameReq)
+	})).Return(&NewGameResponse{GameID: "match-testplayer-2-1", Commitment: "opponent-commitment"}, nil)
 
 	res, err := xl.InitNewGameRequest(req)
 	assert.NoError(err)
-	assert.NotNil(res)
+	assert.Equal("match-testplayer-2-1", res)
+
+	game := xl.games[res]
+	assert.NotNil(game.SelfCommitment)
+	assert.Equal("opponent-commitment", game.OpponentCommitment)
+
+	mockRequester.AssertExpectations(t)
+}
+
+func TestXLSpaceship_InitNewGamePlacement(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+
+	mockRequester := &MockRequester{}
+	xl.requester = mockRequester
+
+	ssProtocol := SpaceshipProtocol{
+		Hostname: "notlocalhost2",
+		Port:     6666,
+	}
+
+	// an unknown strategy fails before we send anything to our opponent
+	_, err := xl.InitNewGameRequest(&InitGameRequest{
+		SpaceshipProtocol: ssProtocol,
+		Placement:         "bogus",
+	})
+	assert.Error(err)
+
+	mockRequester.On("NewGame", ssProtocol, mock.Anything).Return(&NewGameResponse{GameID: "match-testplayer-2-1"}, nil)
+
+	res, err := xl.InitNewGameRequest(&InitGameRequest{
+		SpaceshipProtocol: ssProtocol,
+		Placement:         ssgame.PlacementStrategyEdgeName,
+	})
+	assert.NoError(err)
+	assert.Equal(ssgame.DefaultRules().Fleet.Size(), len(xl.games[res].SelfBoard.Spaceships()))
 
 	mockRequester.AssertExpectations(t)
 }
@@ -277,6 +620,64 @@ func TestXLSpaceship_FireSalvo(t *testing.T) {
 	mockRequester.AssertExpectations(t)
 }
 
+func TestXLSpaceship_FireSalvoWrongCoords(t *testing.T) {
+	assert := require.New(t)
+
+	// our opponent has to report exactly the coords we fired, no more and no less
+	for _, tc := range []struct {
+		salvo map[string]string
+		cheat string
+	}{
+		{map[string]string{"0x0": "miss"}, "no result for 1x1"},
+		{map[string]string{"0x0": "miss", "1x1": "miss", "2x2": "kill"}, "2x2 which wasn't fired"},
+		{map[string]string{"0x0": "miss", "2x2": "kill"}, "2x2 which wasn't fired"},
+	} {
+		xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+		assert.NotNil(xl)
+
+		mockRequester := &MockRequester{}
+		xl.requester = mockRequester
+
+		ssProtocol := SpaceshipProtocol{
+			Hostname: "notlocalhost2",
+			Port:     6666,
+		}
+
+		newGameRes, err := xl.NewGameRequest(&NewGameRequest{
+			UserID:            "testplayer-2",
+			SpaceshipProtocol: ssProtocol,
+		})
+		assert.NoError(err)
+
+		game := xl.games[newGameRes.GameID]
+
+		// make it our turn
+		game.PlayerTurn = ssgame.PlayerSelf
+
+		mockRequester.On("ReceiveSalvo", ssProtocol, ReceiveSalvoRequest{
+			GameID: "match-testplayer-1-1",
+			Salvo:  []string{"0x0", "1x1"},
+		}).Return(&SalvoResponse{
+			Salvo: tc.salvo,
+		}, nil)
+
+		_, _, err = xl.fireSalvo(game, ssgame.CoordsGroup{
+			mustCoordsFromString("0x0"),
+			mustCoordsFromString("1x1"),
+		})
+		assert.Error(err)
+
+		// the game is aborted and none of the results were applied
+		assert.Equal(ssgame.GameStatusDone, game.Status)
+		assert.Equal(ssgame.VerdictCheat, game.Verdict)
+		assert.Contains(game.CheatReason, tc.cheat)
+		assert.Equal(0, len(game.SelfSalvos))
+		assert.Equal(ssgame.CoordsBlank, game.OpponentBoard.CoordsState(mustCoordsFromString("0x0")))
+
+		mockRequester.AssertExpectations(t)
+	}
+}
+
 func TestXLSpaceship_FireSalvoWin(t *testing.T) {
 	assert := require.New(t)
 
@@ -330,7 +731,7 @@ func TestXLSpaceship_FireSalvoWin(t *testing.T) {
 
 	assert.Equal([]string{
 		"X...............",
-		".X..............",
+		".#..............",
 		"................",
 		"................",
 		"................",
@@ -391,3 +792,58 @@ func TestXLSpaceship_FireSalvoAlreadyFinished(t *testing.T) {
 
 	mockRequester.AssertExpectations(t)
 }
+
+func TestXLSpaceship_Hint(t *testing.T) {
+	assert := require.New(t)
+
+	xl := NewXLSpaceship("testplayer-1", "Test Player 1", "notlocalhost", 1337)
+	assert.NotNil(xl)
+
+	_, err := xl.HintRequest(&HintRequest{GameID: "bogus"})
+	assert.Error(err)
+
+	newGameRes, err := xl.NewGameRequest(&NewGameRequest{
+		UserID: "testplayer-2",
+		SpaceshipProtocol: SpaceshipProtocol{
+			Hostname: "notlocalhost2",
+			Port:     6666,
+		},
+	})
+	assert.NoError(err)
+
+	game := xl.games[newGameRes.GameID]
+
+	// we already fired at everything except for the cells around a hit
+	blank := map[string]bool{"7x6": true, "6x7": true, "8x7": true, "7x8": true}
+	for y := 0; y < game.Rules.Height; y++ {
+		for x := 0; x < game.Rules.Width; x++ {
+			coords := ssgame.NewCoords(x, y)
+			if blank[coords.String()] {
+				continue
+			}
+
+			if coords.String() == "7x7" {
+				game.OpponentBoard.ApplyShotStatus(coords, ssgame.ShotStatusHit)
+			} else {
+				game.OpponentBoard.ApplyShotStatus(coords, ssgame.ShotStatusMiss)
+			}
+		}
+	}
+
+	for _, difficulty := range []string{"easy", "medium", "hard", ""} {
+		res, err := xl.HintRequest(&HintRequest{GameID: game.GameID, Difficulty: difficulty})
+		assert.NoError(err)
+		assert.Equal(4, len(res.Salvo))
+		for _, coords := range res.Salvo {
+			assert.True(blank[coords], "%s: %s was already fired at", difficulty, coords)
+		}
+	}
+
+	_, err = xl.HintRequest(&HintRequest{GameID: game.GameID, Difficulty: "bogus"})
+	assert.Error(err)
+
+	// no hints once the game is done
+	assert.NoError(game.Win(ssgame.PlayerSelf))
+	_, err = xl.HintRequest(&HintRequest{GameID: game.GameID})
+	assert.Error(err)
+}
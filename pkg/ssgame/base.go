@@ -5,21 +5,11 @@ import (
 	"regexp"
 	"strconv"
 
-	"math/rand"
-
 	"github.com/pkg/errors"
 )
 
-func init() {
-	// this should be replaced by crypto/rand with proper seeding for secure random numbers
-	//  but for this exercise it's much nicer if it's not really random
-	rand.Seed(1)
-}
-
-const ROWS = 16
-const COLS = 16
-
-var coordsRegex = regexp.MustCompile(`^([0-9a-fA-F])[xX]([0-9a-fA-F])$`)
+// coords are 1 or 2 hex digits (without leading zero) for X and Y seperated by an x
+var coordsRegex = regexp.MustCompile(`^([0-9a-fA-F]|[1-9a-fA-F][0-9a-fA-F])[xX]([0-9a-fA-F]|[1-9a-fA-F][0-9a-fA-F])$`)
 
 type CoordsState byte
 
@@ -33,6 +23,8 @@ func (c CoordsState) String() string {
 		return CoordsHitStr
 	case CoordsMiss:
 		return CoordsMissStr
+	case CoordsSunk:
+		return CoordsSunkStr
 	}
 
 	panic("Unreachable")
@@ -43,11 +35,14 @@ const (
 	CoordsShip  CoordsState = '*'
 	CoordsHit   CoordsState = 'X'
 	CoordsMiss  CoordsState = '-'
+	// a hit on a spaceship that we know is killed
+	CoordsSunk CoordsState = '#'
 
 	CoordsBlankStr string = "."
 	CoordsShipStr  string = "*"
 	CoordsHitStr   string = "X"
 	CoordsMissStr  string = "-"
+	CoordsSunkStr  string = "#"
 )
 
 type ShotStatus int8
@@ -88,12 +83,6 @@ const (
 	ShotStatusKillStr string = "kill"
 )
 
-type GridCell struct {
-	coords    *Coords
-	state     CoordsState
-	spaceship *Spaceship
-}
-
 type Coords struct {
 	x int8
 	y int8
@@ -119,10 +108,38 @@ func CoordsFromString(coordsStr string) (*Coords, error) {
 	return coords, nil
 }
 
+func NewCoords(x int, y int) *Coords {
+	return &Coords{x: int8(x), y: int8(y)}
+}
+
+func (c Coords) X() int {
+	return int(c.x)
+}
+
+func (c Coords) Y() int {
+	return int(c.y)
+}
+
 func (c Coords) String() string {
 	return fmt.Sprintf("%Xx%X", c.x, c.y)
 }
 
+// the 8 coords surrounding the coords, can be out of bounds
+func (c Coords) neighbours() CoordsGroup {
+	neighbours := make(CoordsGroup, 0, 8)
+	for _, dy := range []int8{-1, 0, 1} {
+		for _, dx := range []int8{-1, 0, 1} {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+
+			neighbours = append(neighbours, &Coords{x: c.x + dx, y: c.y + dy})
+		}
+	}
+
+	return neighbours
+}
+
 type CoordsGroup []*Coords
 
 func CoordsGroupFromSalvoStrings(salvo []string) (CoordsGroup, error) {
@@ -158,7 +175,9 @@ func (cg CoordsGroup) Copy() CoordsGroup {
 	return newCg
 }
 
+// Spaceship is the name of the spaceship that was killed by the shot, it's only set for a kill when the name is known
 type ShotResult struct {
-	Coords     *Coords
-	ShotStatus ShotStatus
+	Coords     *Coords    `json:"coords"`
+	ShotStatus ShotStatus `json:"shot_status"`
+	Spaceship  string     `json:"spaceship,omitempty"`
 }
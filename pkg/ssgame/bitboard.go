@@ -0,0 +1,164 @@
+package ssgame
+
+import (
+	"math/bits"
+)
+
+// a set of cells on a board, with a row for every y and bit x of the row for x
+//  it's big enough for the biggest board we allow and it's an array so it can be copied and compared with ==
+type Bitboard [MaxHeight]uint32
+
+// the bitboard with the coords set, false when some of the coords don't fit on a bitboard
+func BitboardFromCoords(cg CoordsGroup) (Bitboard, bool) {
+	var b Bitboard
+	for _, coords := range cg {
+		if !inBitboard(coords) {
+			return b, false
+		}
+
+		b.Set(coords)
+	}
+
+	return b, true
+}
+
+// the bitboard with every cell of the board set
+func BoundsBitboard(rules *Rules) Bitboard {
+	var b Bitboard
+	for y := 0; y < rules.Height; y++ {
+		b[y] = ^uint32(0) >> uint(MaxWidth-rules.Width)
+	}
+
+	return b
+}
+
+func inBitboard(coords *Coords) bool {
+	return coords.x >= 0 && int(coords.x) < MaxWidth && coords.y >= 0 && int(coords.y) < MaxHeight
+}
+
+// set the coords, the coords should fit on a bitboard
+func (b *Bitboard) Set(coords *Coords) {
+	b[coords.y] |= 1 << uint(coords.x)
+}
+
+// unset the coords, the coords should fit on a bitboard
+func (b *Bitboard) Unset(coords *Coords) {
+	b[coords.y] &^= 1 << uint(coords.x)
+}
+
+// check if the coords are set, coords that don't fit on a bitboard never are
+func (b Bitboard) Has(coords *Coords) bool {
+	return inBitboard(coords) && b[coords.y]&(1<<uint(coords.x)) != 0
+}
+
+// the cells that are set on either bitboard
+func (b Bitboard) Union(other Bitboard) Bitboard {
+	for y := range b {
+		b[y] |= other[y]
+	}
+
+	return b
+}
+
+// the cells that are set on both bitboards
+func (b Bitboard) Intersect(other Bitboard) Bitboard {
+	for y := range b {
+		b[y] &= other[y]
+	}
+
+	return b
+}
+
+// the cells that are set on this bitboard but not on the other
+func (b Bitboard) Difference(other Bitboard) Bitboard {
+	for y := range b {
+		b[y] &^= other[y]
+	}
+
+	return b
+}
+
+// check if any cell is set on both bitboards, without building the intersection
+func (b Bitboard) Intersects(other Bitboard) bool {
+	for y := range b {
+		if b[y]&other[y] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b Bitboard) Empty() bool {
+	for _, row := range b {
+		if row != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b Bitboard) Count() int {
+	count := 0
+	for _, row := range b {
+		count += bits.OnesCount32(row)
+	}
+
+	return count
+}
+
+// move all the cells x to the right and y down, cells that end up outside of the bitboard are dropped
+func (b Bitboard) Offset(x int, y int) Bitboard {
+	var res Bitboard
+	for row := 0; row+y < len(b); row++ {
+		res[row+y] = b[row] << uint(x)
+	}
+
+	return res
+}
+
+// the cells and the 8 cells surrounding every one of them
+func (b Bitboard) Neighbourhood() Bitboard {
+	var res Bitboard
+	for y, row := range b {
+		// spread the row left and right, and then to the rows above and below it
+		row |= row<<1 | row>>1
+
+		res[y] |= row
+		if y > 0 {
+			res[y-1] |= row
+		}
+		if y < len(b)-1 {
+			res[y+1] |= row
+		}
+	}
+
+	return res
+}
+
+// the first cell that is set (top to bottom, left to right), false when none is
+func (b Bitboard) First() (*Coords, bool) {
+	for y, row := range b {
+		if row != 0 {
+			return &Coords{x: int8(bits.TrailingZeros32(row)), y: int8(y)}, true
+		}
+	}
+
+	return nil, false
+}
+
+// the coords of all the cells that are set (top to bottom, left to right)
+func (b Bitboard) Coords() CoordsGroup {
+	cg := make(CoordsGroup, 0, b.Count())
+	for y, row := range b {
+		for row != 0 {
+			x := bits.TrailingZeros32(row)
+			row &^= 1 << uint(x)
+
+			cg = append(cg, &Coords{x: int8(x), y: int8(y)})
+		}
+	}
+
+	return cg
+}
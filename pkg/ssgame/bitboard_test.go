@@ -0,0 +1,94 @@
+package ssgame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitboard(t *testing.T) {
+	assert := require.New(t)
+
+	b, ok := BitboardFromCoords(CoordsGroup{NewCoords(0, 0), NewCoords(1, 0), NewCoords(31, 31)})
+	assert.True(ok)
+	assert.Equal(3, b.Count())
+	assert.True(b.Has(NewCoords(1, 0)))
+	assert.True(b.Has(NewCoords(31, 31)))
+	assert.False(b.Has(NewCoords(0, 1)))
+	assert.False(b.Has(NewCoords(-1, 0)))
+	assert.False(b.Has(NewCoords(32, 0)))
+
+	_, ok = BitboardFromCoords(CoordsGroup{NewCoords(0, 0), NewCoords(-1, 0)})
+	assert.False(ok)
+
+	b.Unset(NewCoords(31, 31))
+	assert.Equal(CoordsGroup{NewCoords(0, 0), NewCoords(1, 0)}, b.Coords())
+
+	first, ok := b.First()
+	assert.True(ok)
+	assert.Equal(NewCoords(0, 0), first)
+
+	var empty Bitboard
+	assert.True(empty.Empty())
+	_, ok = empty.First()
+	assert.False(ok)
+}
+
+func TestBitboardSetOperations(t *testing.T) {
+	assert := require.New(t)
+
+	a, _ := BitboardFromCoords(CoordsGroup{NewCoords(0, 0), NewCoords(1, 0)})
+	b, _ := BitboardFromCoords(CoordsGroup{NewCoords(1, 0), NewCoords(2, 0)})
+	c, _ := BitboardFromCoords(CoordsGroup{NewCoords(5, 5)})
+
+	union := a.Union(b)
+	assert.Equal(CoordsGroup{NewCoords(0, 0), NewCoords(1, 0), NewCoords(2, 0)}, union.Coords())
+	intersection := a.Intersect(b)
+	assert.Equal(CoordsGroup{NewCoords(1, 0)}, intersection.Coords())
+	difference := a.Difference(b)
+	assert.Equal(CoordsGroup{NewCoords(0, 0)}, difference.Coords())
+
+	assert.True(a.Intersects(b))
+	assert.False(a.Intersects(c))
+
+	// the operations don't modify the bitboards they're called on
+	assert.Equal(2, a.Count())
+	assert.Equal(2, b.Count())
+}
+
+func TestBitboardNeighbourhood(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{Width: 4, Height: 4}
+
+	b, _ := BitboardFromCoords(CoordsGroup{NewCoords(0, 0), NewCoords(3, 3)})
+	neighbourhood := b.Neighbourhood().Intersect(BoundsBitboard(rules))
+
+	board := newBaseBoard(rules)
+	assert.NoError(FillBoardFromPattern(board, BlankBoardPattern(rules)))
+	board.ships = neighbourhood
+
+	assert.Equal([]string{
+		"**..",
+		"**..",
+		"..**",
+		"..**",
+	}, board.ToPattern())
+
+	// the last column and row of the biggest board don't spill over
+	b, _ = BitboardFromCoords(CoordsGroup{NewCoords(MaxWidth-1, MaxHeight-1)})
+	assert.Equal(4, b.Neighbourhood().Count())
+}
+
+func TestBoundsBitboard(t *testing.T) {
+	assert := require.New(t)
+
+	bounds := BoundsBitboard(&Rules{Width: 3, Height: 2})
+	assert.Equal(6, bounds.Count())
+	assert.True(bounds.Has(NewCoords(2, 1)))
+	assert.False(bounds.Has(NewCoords(3, 1)))
+	assert.False(bounds.Has(NewCoords(2, 2)))
+
+	bounds = BoundsBitboard(&Rules{Width: MaxWidth, Height: MaxHeight})
+	assert.Equal(MaxWidth*MaxHeight, bounds.Count())
+}
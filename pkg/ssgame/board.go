@@ -9,102 +9,117 @@ import (
 )
 
 // helper function for a blank board
-func BlankBoardPattern() []string {
-	return []string{
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
-		"................",
+func BlankBoardPattern(rules *Rules) []string {
+	pattern := make([]string, rules.Height)
+	for y := range pattern {
+		pattern[y] = strings.Repeat(CoordsBlankStr, rules.Width)
 	}
+
+	return pattern
 }
 
 // the base type for our boards to share
+//  the state of every cell is stored as the bitboards of the cells with a spaceship, a hit, a miss and a sunk spaceship,
+//  blank cells are on none of them
 type BaseBoard struct {
-	grid [][]*GridCell
+	rules  *Rules
+	ships  Bitboard
+	hits   Bitboard
+	misses Bitboard
+	sunk   Bitboard
 }
 
 // our own board which contains our own placed shaceships
+//  spaceshipCells are the cells of all our spaceships, hit or not
 type SelfBoard struct {
 	*BaseBoard
-	spaceships []*Spaceship
+	spaceships     []*Spaceship
+	spaceshipCells Bitboard
 }
 
 // our opponent's board for which we don't know his spaceships, we do know how many there are left alive
+//  killed are the names of the spaceships we killed, for the kills our opponent told us the name of
+//  or where only 1 spaceship of the fleet fit our hits
 type OpponentBoard struct {
 	*BaseBoard
 	spaceshipsAlive uint8
+	killed          []string
 }
 
 // generate a random board for ourselves with the specified spaceships
-//  we retry to create a random board 100 times incase the spaceships didn't fit
-func NewRandomSelfBoard(spaceships [][]string) (*SelfBoard, error) {
-	for i := 0; i < 100; i++ {
-		board, err := newRandomSelfBoard(spaceships)
+//  without a strategy every layout of the spaceships on the board is as likely, unless the board is so crowded
+//  that we hardly ever find one that way, then we search for one by picking the positions one spaceship at a time
+//  this only fails when there's no way at all to place the spaceships on the board
+//  the strategy decides which positions are more likely, when it's nil every position is as likely
+//  spaceships that are part of the fleet of the rules get their name from the fleet
+func NewRandomSelfBoard(rules *Rules, spaceships [][]string, strategy PlacementStrategy, rng *rand.Rand) (*SelfBoard, error) {
+	board, err := NewBlankSelfBoard(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// the same spaceships share their positions
+	positions := make([][]*spaceshipPosition, len(spaceships))
+	patternPositions := make(map[string][]*spaceshipPosition)
+	names := make([]string, len(spaceships))
+	patternNames := rules.Fleet.namesByPattern()
+	for i, spaceshipPattern := range spaceships {
+		spaceship, err := SpaceshipFromPattern(spaceshipPattern)
 		if err != nil {
 			return nil, err
 		}
 
-		if board != nil {
-			return board, nil
+		key := strings.Join(spaceshipPattern, "\n")
+		if _, ok := patternPositions[key]; !ok {
+			patternPositions[key] = spaceshipPositions(rules, spaceship)
+		}
+		positions[i] = patternPositions[key]
+
+		if len(patternNames[key]) > 0 {
+			names[i], patternNames[key] = patternNames[key][0], patternNames[key][1:]
 		}
 	}
 
-	return nil, errors.New("Failed to create a random board")
-}
+	var weigher PlacementWeigher
+	if strategy != nil {
+		weigher = strategy.Weigher(rules)
+	}
 
-// generate a random board for ourselves with the specified spaceships
-//  internal function for NewRandomSelfBoard to use
-// board can be nil when we failed to place a spaceship
-func newRandomSelfBoard(spaceships [][]string) (*SelfBoard, error) {
-	board, err := NewBlankSelfBoard()
+	placed, err := placeRandom(rules, positions, weigher, rng)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, spaceshipPattern := range spaceships {
-		spaceship, err := SpaceshipFromPattern(spaceshipPattern)
-		if err != nil {
-			return nil, err
-		}
+	for i, position := range placed {
+		spaceship := position.spaceship()
+		spaceship.name = names[i]
 
-		// attempt to add spaceship, if we fail we nil the board so that we keep trying
-		err = board.AddSpaceship(spaceship)
+		err = board.AddSpaceshipOnCoords(spaceship)
 		if err != nil {
-			board = nil
-			break
+			return nil, err
 		}
 	}
 
 	return board, nil
 }
 
-func newBaseBoard() *BaseBoard {
-	return &BaseBoard{}
+func newBaseBoard(rules *Rules) *BaseBoard {
+	return &BaseBoard{
+		rules: rules,
+	}
 }
 
-func NewSelfBoard() *SelfBoard {
+func NewSelfBoard(rules *Rules) *SelfBoard {
 	return &SelfBoard{
-		BaseBoard:  newBaseBoard(),
+		BaseBoard:  newBaseBoard(rules),
 		spaceships: make([]*Spaceship, 0),
 	}
 }
 
-func NewBlankSelfBoard() (*SelfBoard, error) {
-	board := NewSelfBoard()
+func NewBlankSelfBoard(rules *Rules) (*SelfBoard, error) {
+	board := NewSelfBoard(rules)
 
-	err := FillBoardFromPattern(board.BaseBoard, BlankBoardPattern())
+	err := FillBoardFromPattern(board.BaseBoard, BlankBoardPattern(rules))
 	if err != nil {
 		return nil, err
 	}
@@ -112,17 +127,17 @@ func NewBlankSelfBoard() (*SelfBoard, error) {
 	return board, nil
 }
 
-func NewOpponentBoard(spaceshipsAlive uint8) *OpponentBoard {
+func NewOpponentBoard(rules *Rules, spaceshipsAlive uint8) *OpponentBoard {
 	return &OpponentBoard{
-		BaseBoard:       newBaseBoard(),
+		BaseBoard:       newBaseBoard(rules),
 		spaceshipsAlive: spaceshipsAlive,
 	}
 }
 
-func NewBlankOpponentBoard(spaceshipsAlive uint8) (*OpponentBoard, error) {
-	board := NewOpponentBoard(spaceshipsAlive)
+func NewBlankOpponentBoard(rules *Rules, spaceshipsAlive uint8) (*OpponentBoard, error) {
+	board := NewOpponentBoard(rules, spaceshipsAlive)
 
-	err := FillBoardFromPattern(board.BaseBoard, BlankBoardPattern())
+	err := FillBoardFromPattern(board.BaseBoard, BlankBoardPattern(rules))
 	if err != nil {
 		return nil, err
 	}
@@ -130,54 +145,72 @@ func NewBlankOpponentBoard(spaceshipsAlive uint8) (*OpponentBoard, error) {
 	return board, nil
 }
 
-// fill a board with a pattern
+// fill a board with a pattern, the pattern should match the dimensions of the board's rules
 func FillBoardFromPattern(board *BaseBoard, pattern []string) error {
 	// sanity check the input
-	if len(pattern) != ROWS {
+	if len(pattern) != board.rules.Height {
 		return errors.New("pattern incorrect amount of rows")
 	}
 
 	// sanity check the input
 	for _, row := range pattern {
-		if len(row) != COLS {
+		if len(row) != board.rules.Width {
 			return errors.New("pattern incorrect amount of cols")
 		}
 
 		// @TODO: is there a nicer way to do this with a builtin?
 		for _, char := range []byte(row) {
-			if char != byte(CoordsBlank) && char != byte(CoordsShip) && char != byte(CoordsHit) && char != byte(CoordsMiss) {
+			if char != byte(CoordsBlank) && char != byte(CoordsShip) && char != byte(CoordsHit) && char != byte(CoordsMiss) && char != byte(CoordsSunk) {
 				return errors.New("pattern incorrect symbol for coords")
 			}
 		}
 	}
 
-	// init the grid with rows
-	board.grid = make([][]*GridCell, ROWS)
-
-	// parse the input and add them to the grid
+	// parse the input and add them to the bitboards
+	board.ships, board.hits, board.misses, board.sunk = Bitboard{}, Bitboard{}, Bitboard{}, Bitboard{}
 	for y, row := range pattern {
-		board.grid[y] = make([]*GridCell, COLS)
-
 		for x, char := range []byte(row) {
-			coordsState := CoordsState(char)
-
-			board.grid[y][x] = &GridCell{
-				coords: &Coords{x: int8(x), y: int8(y)},
-				state:  coordsState,
-			}
+			board.setCoordsState(&Coords{x: int8(x), y: int8(y)}, CoordsState(char))
 		}
 	}
 
 	return nil
 }
 
+// set the state of the cell on the coords, the coords should be within the bounds of the board
+func (b *BaseBoard) setCoordsState(coords *Coords, state CoordsState) {
+	b.ships.Unset(coords)
+	b.hits.Unset(coords)
+	b.misses.Unset(coords)
+	b.sunk.Unset(coords)
+
+	switch state {
+	case CoordsShip:
+		b.ships.Set(coords)
+	case CoordsHit:
+		b.hits.Set(coords)
+	case CoordsMiss:
+		b.misses.Set(coords)
+	case CoordsSunk:
+		b.sunk.Set(coords)
+	}
+}
+
+// mark the cells as the cells of a sunk spaceship
+func (b *BaseBoard) sink(cells Bitboard) {
+	b.ships = b.ships.Difference(cells)
+	b.hits = b.hits.Difference(cells)
+	b.misses = b.misses.Difference(cells)
+	b.sunk = b.sunk.Union(cells)
+}
+
 func (b *BaseBoard) buildPattern() [][]byte {
-	pattern := make([][]byte, ROWS)
-	for y, row := range b.grid {
-		pattern[y] = make([]byte, COLS)
+	pattern := make([][]byte, b.rules.Height)
+	for y := range pattern {
+		pattern[y] = make([]byte, b.rules.Width)
 
-		for x, cell := range row {
-			pattern[y][x] = byte(cell.state)
+		for x := range pattern[y] {
+			pattern[y][x] = byte(b.CoordsState(&Coords{x: int8(x), y: int8(y)}))
 		}
 	}
 
@@ -186,7 +219,7 @@ func (b *BaseBoard) buildPattern() [][]byte {
 
 func (b *BaseBoard) patternToStrings(pattern [][]byte) []string {
 	// turn the byte arrays into strings
-	res := make([]string, ROWS)
+	res := make([]string, b.rules.Height)
 	for y, row := range pattern {
 		res[y] = string(row)
 	}
@@ -200,90 +233,69 @@ func (b *BaseBoard) ToPattern() []string {
 	return b.patternToStrings(pattern)
 }
 
-// Count the hits, could be stored internally instead of recounting every time, but we don't actually use this outside of tests currently
+// the cells of sunk spaceships were hits too
 func (b *BaseBoard) CountHits() int {
-	hits := 0
-
-	for _, row := range b.grid {
-		for _, cell := range row {
-			if cell.state == CoordsHit {
-				hits++
-			}
-		}
-	}
-
-	return hits
+	return b.hits.Count() + b.sunk.Count()
 }
 
-// Count the misses, could be stored internally instead of recounting every time, but we don't actually use this outside of tests currently
 func (b *BaseBoard) CountMisses() int {
-	misses := 0
-
-	for _, row := range b.grid {
-		for _, cell := range row {
-			if cell.state == CoordsMiss {
-				misses++
-			}
-		}
-	}
-
-	return misses
+	return b.misses.Count()
 }
 
-// attempt to add a spaceship on random locations until we succeed
-//  if we reach the max N attempts then just error out
-func (b *SelfBoard) AddSpaceship(spaceship *Spaceship) error {
-	N := 10000
-	// @TODO: this could be heavily optimized as we know we don't have to try adding a spaceship of 3 high on Y > 15 - 3
-	for i := 0; i < N; i++ {
-		// randomize x, y offset and rotation
-		x := rand.Intn(COLS)
-		y := rand.Intn(ROWS)
-		rotate := rand.Intn(3) * 90
-
-		newSpaceship := spaceship.CopyWithOffset(int8(x), int8(y)).CopyWithRotate(uint16(rotate))
+// add a spaceship on a random position, picked uniformly from the positions it can legally be placed on
+//  errors when there's no room left for the spaceship anywhere on the board
+func (b *SelfBoard) AddSpaceship(spaceship *Spaceship, rng *rand.Rand) error {
+	blocked := b.spaceshipCells
+	if b.rules.NoTouch {
+		blocked = blocked.Neighbourhood()
+	}
 
-		err := b.AddSpaceshipOnCoords(newSpaceship)
-		// we're done if no err
-		if err == nil {
-			return nil
-		}
+	legal := legalPositions(spaceshipPositions(b.rules, spaceship), blocked)
+	if len(legal) == 0 {
+		return errors.New("Failed to add spaceship, there's no room left for it")
 	}
 
-	return errors.New("Failed to add spaceship, seems impossible")
+	return b.AddSpaceshipOnCoords(legal[rng.Intn(len(legal))].spaceship())
 }
 
 // add a spaceship on specified locations
 //  will error when it's out of bound or overlapping with an existing spaceship
+//  or when the rules don't allow spaceships to touch and it's next to an existing spaceship
 func (b *SelfBoard) AddSpaceshipOnCoords(spaceship *Spaceship) error {
-	// @TODO: we should store coords of existing spaceships so we don't have to loop over them
+	// the cells a spaceship can't be placed on to not touch other spaceships
+	var touching Bitboard
+	if b.rules.NoTouch {
+		touching = b.spaceshipCells.Neighbourhood()
+	}
+
 	for _, coords := range spaceship.coords {
 		// check spaceship stays within bounds
-		if coords.x >= COLS {
-			return errors.New(fmt.Sprintf("Failed to add spaceship, y overflow (%s)", coords))
-		}
-		if coords.y >= ROWS {
+		if coords.x < 0 || int(coords.x) >= b.rules.Width {
 			return errors.New(fmt.Sprintf("Failed to add spaceship, x overflow (%s)", coords))
 		}
+		if coords.y < 0 || int(coords.y) >= b.rules.Height {
+			return errors.New(fmt.Sprintf("Failed to add spaceship, y overflow (%s)", coords))
+		}
 
 		// check spaceship doesn't overlap with other spaceships
-		for _, otherSpaceship := range b.spaceships {
-			if otherSpaceship.coords.Contains(coords) {
-				return errors.New(fmt.Sprintf("Failed to add spaceship, coords already contains spaceship (%s)", coords))
-			}
+		if b.spaceshipCells.Has(coords) {
+			return errors.New(fmt.Sprintf("Failed to add spaceship, coords already contains spaceship (%s)", coords))
+		}
+
+		// check spaceship isn't touching other spaceships (also diagonally)
+		if touching.Has(coords) {
+			return errors.New(fmt.Sprintf("Failed to add spaceship, coords touching spaceship (%s)", coords))
 		}
 	}
 
 	// add spaceship to board
+	spaceship.place()
 	b.spaceships = append(b.spaceships, spaceship)
+	b.spaceshipCells = b.spaceshipCells.Union(spaceship.cells)
 
-	// add spaceship to grid
-	for _, coords := range spaceship.coords {
-		if b.grid[coords.y][coords.x].state != CoordsHit {
-			b.grid[coords.y][coords.x].state = CoordsShip
-		}
-		b.grid[coords.y][coords.x].spaceship = spaceship
-	}
+	// cells that were already hit stay hit
+	b.ships = b.ships.Union(spaceship.cells.Difference(b.hits).Difference(b.sunk))
+	b.misses = b.misses.Difference(spaceship.cells)
 
 	return nil
 }
@@ -301,39 +313,42 @@ func (b *SelfBoard) ReceiveSalvo(salvo CoordsGroup) []*ShotResult {
 // apply a shot to our board
 func (b *SelfBoard) ApplyShot(shot *Coords) *ShotResult {
 	status := ShotStatusMiss
+	name := ""
 
 	// check if shot is within bounds of our grid
-	if int(shot.y) < len(b.grid) && int(shot.x) < len(b.grid[shot.y]) {
-		cell := b.grid[shot.y][shot.x]
-
+	if b.rules.InBounds(shot) {
 		// check if shot was on a ship (note; previous hits will fail because they're already CoordsHit), this is intended
-		if cell.state == CoordsShip {
-			cell.state = CoordsHit
+		if b.ships.Has(shot) {
+			b.setCoordsState(shot, CoordsHit)
 
-			if cell.spaceship.coords.Contains(shot) && !cell.spaceship.hits.Contains(shot) {
+			spaceship := b.spaceshipOn(shot)
+			if spaceship != nil && !spaceship.hitCells.Has(shot) {
 				status = ShotStatusHit
 
 				// add the coords as a hit
-				cell.spaceship.hits = append(cell.spaceship.hits, shot)
-
-				fmt.Printf("")
+				spaceship.hits = append(spaceship.hits, shot)
+				spaceship.hitCells.Set(shot)
 
-				// if we've hit all the coords then it's a kill
-				if len(cell.spaceship.hits) == len(cell.spaceship.coords) {
-					cell.spaceship.dead = true
+				// if we've hit all the coords then it's a kill and all of it's cells are sunk
+				if spaceship.hitCells == spaceship.cells {
+					spaceship.dead = true
 					status = ShotStatusKill
+					name = spaceship.name
+
+					b.sink(spaceship.cells)
 				}
 			}
-		} else if cell.state == CoordsHit {
+		} else if b.hits.Has(shot) || b.sunk.Has(shot) {
 			// nothing to do, already a hit so leave untouched and return MISS
 		} else {
-			cell.state = CoordsMiss
+			b.setCoordsState(shot, CoordsMiss)
 		}
 	}
 
 	res := &ShotResult{
 		shot,
 		status,
+		name,
 	}
 
 	return res
@@ -341,25 +356,124 @@ func (b *SelfBoard) ApplyShot(shot *Coords) *ShotResult {
 
 // apply one of our shots to opponent's board using the status our opponent told us of the shot
 func (b *OpponentBoard) ApplyShotStatus(shot *Coords, status ShotStatus) {
+	b.applyShot(shot, status, "")
+}
+
+// apply one of our shots to opponent's board using the result our opponent told us of the shot,
+//  which includes the name of the spaceship we killed when our opponent told us
+func (b *OpponentBoard) ApplyShotResult(shotRes *ShotResult) {
+	b.applyShot(shotRes.Coords, shotRes.ShotStatus, shotRes.Spaceship)
+}
+
+func (b *OpponentBoard) applyShot(shot *Coords, status ShotStatus, name string) {
 
 	// check if shot is within bounds of our grid
-	if int(shot.y) < len(b.grid) && int(shot.x) < len(b.grid[shot.y]) {
+	if b.rules.InBounds(shot) {
 		switch status {
 		case ShotStatusMiss:
-			b.grid[shot.y][shot.x].state = CoordsMiss
+			b.setCoordsState(shot, CoordsMiss)
 		case ShotStatusHit:
-			b.grid[shot.y][shot.x].state = CoordsHit
+			b.setCoordsState(shot, CoordsHit)
 		case ShotStatusKill:
-			b.grid[shot.y][shot.x].state = CoordsHit
+			// a spaceship we already sunk can't be killed again and we can't kill more spaceships than are alive
+			if b.sunk.Has(shot) || b.spaceshipsAlive == 0 {
+				return
+			}
+
+			b.setCoordsState(shot, CoordsHit)
+
+			cells, killed := b.killedCells(shot, name)
+			b.sink(cells)
 			b.spaceshipsAlive--
+
+			if killed != "" {
+				b.killed = append(b.killed, killed)
+			}
 		}
 	}
 }
 
+// the cells of the spaceship that was killed by the shot on the coords, as far as we can tell from our hits
+//  every position of a spaceship of the fleet that's still alive that covers the kill and only our (not sunk) hits could be the spaceship we killed,
+//  the cells that all of those positions have in common are surely part of it, when there's only 1 position that's the whole spaceship
+//  when no position fits (or they only have the kill in common) it's only the kill
+//  when we know the name of the spaceship we killed only the positions of that spaceship could be it,
+//  when we don't but all positions are of the same spaceship we know it's name now and it's returned as well
+//  a name that's not part of the fleet, or of which all spaceships are already dead, can't be right so it's ignored
+func (b *OpponentBoard) killedCells(kill *Coords, name string) (Bitboard, string) {
+	var cells Bitboard
+	cells.Set(kill)
+
+	killed := b.killedCounts()
+	named := killed[name] < b.rules.Fleet.count(name)
+	if !named {
+		name = ""
+	}
+
+	found := false
+	var common Bitboard
+	candidates := make(map[string]bool)
+	for _, fleetSpaceship := range b.rules.Fleet {
+		if named && fleetSpaceship.Name != name {
+			continue
+		}
+
+		// all spaceships with this name are already dead
+		if killed[fleetSpaceship.Name] >= fleetSpaceship.Count {
+			continue
+		}
+
+		spaceship, err := SpaceshipFromPattern(fleetSpaceship.Pattern)
+		if err != nil {
+			continue
+		}
+
+		for _, position := range spaceshipPositions(b.rules, spaceship) {
+			if !position.cells.Has(kill) || !position.cells.Difference(b.hits).Empty() {
+				continue
+			}
+
+			candidates[fleetSpaceship.Name] = true
+			if !found {
+				found, common = true, position.cells
+			} else {
+				common = common.Intersect(position.cells)
+			}
+		}
+	}
+
+	if name == "" && len(candidates) == 1 {
+		for candidate := range candidates {
+			name = candidate
+		}
+	}
+
+	return cells.Union(common), name
+}
+
+func (b *BaseBoard) Rules() *Rules {
+	return b.rules
+}
+
 func (b *SelfBoard) Spaceships() []*Spaceship {
 	return b.spaceships
 }
 
+// the spaceship on the coords, nil when there's none
+func (b *SelfBoard) spaceshipOn(coords *Coords) *Spaceship {
+	if !b.spaceshipCells.Has(coords) {
+		return nil
+	}
+
+	for _, spaceship := range b.spaceships {
+		if spaceship.cells.Has(coords) {
+			return spaceship
+		}
+	}
+
+	return nil
+}
+
 func (b *SelfBoard) CountShipsAlive() int {
 	i := 0
 	for _, spaceship := range b.spaceships {
@@ -371,6 +485,27 @@ func (b *SelfBoard) CountShipsAlive() int {
 	return i
 }
 
+func (b *SelfBoard) CountCellsAlive() int {
+	i := 0
+	for _, spaceship := range b.spaceships {
+		i += len(spaceship.coords) - len(spaceship.hits)
+	}
+
+	return i
+}
+
+// the names of our spaceships that are alive
+func (b *SelfBoard) FleetAlive() []string {
+	names := make([]string, 0, len(b.spaceships))
+	for _, spaceship := range b.spaceships {
+		if !spaceship.dead {
+			names = append(names, spaceship.name)
+		}
+	}
+
+	return names
+}
+
 func (b *SelfBoard) AllShipsDead() bool {
 	return b.CountShipsAlive() == 0
 }
@@ -379,10 +514,65 @@ func (b *OpponentBoard) CountShipsAlive() int {
 	return int(b.spaceshipsAlive)
 }
 
+// we don't know where our opponent's spaceships are, but every hit we made is a cell less
+func (b *OpponentBoard) CountCellsAlive() int {
+	return b.rules.Fleet.Cells() - b.CountHits()
+}
+
+// the names of the spaceships of our opponent we know we killed, a name is repeated for every spaceship we killed with it
+func (b *OpponentBoard) Killed() []string {
+	return b.killed
+}
+
+// the number of spaceships we know we killed by their name
+func (b *OpponentBoard) killedCounts() map[string]int {
+	killed := make(map[string]int, len(b.killed))
+	for _, name := range b.killed {
+		killed[name]++
+	}
+
+	return killed
+}
+
+// the names of our opponent's spaceships that are alive, a name is repeated for every spaceship of the fleet with it
+//  we only know this when we know the name of every spaceship we killed, otherwise it's nil
+func (b *OpponentBoard) FleetAlive() []string {
+	if len(b.killed) != b.rules.Fleet.Size()-b.CountShipsAlive() {
+		return nil
+	}
+
+	killed := b.killedCounts()
+
+	names := make([]string, 0, b.CountShipsAlive())
+	for _, fleetSpaceship := range b.rules.Fleet {
+		for i := killed[fleetSpaceship.Name]; i < fleetSpaceship.Count; i++ {
+			names = append(names, fleetSpaceship.Name)
+		}
+	}
+
+	return names
+}
+
 func (b *OpponentBoard) AllShipsDead() bool {
 	return b.spaceshipsAlive == 0
 }
 
+// the state of the cell on the coords, the coords should be within the bounds of the board
+func (b *BaseBoard) CoordsState(coords *Coords) CoordsState {
+	switch {
+	case b.ships.Has(coords):
+		return CoordsShip
+	case b.hits.Has(coords):
+		return CoordsHit
+	case b.misses.Has(coords):
+		return CoordsMiss
+	case b.sunk.Has(coords):
+		return CoordsSunk
+	default:
+		return CoordsBlank
+	}
+}
+
 func (b *BaseBoard) String() string {
 	return fmt.Sprintf("%s", strings.Join(b.ToPattern(), "\n"))
 }
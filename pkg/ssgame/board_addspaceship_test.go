@@ -9,7 +9,7 @@ import (
 func TestBoard_AddSpaceshipOnCoordsSimple0x0(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 
 	spaceship, err := SpaceshipFromPattern([]string{
@@ -24,7 +24,7 @@ func TestBoard_AddSpaceshipOnCoordsSimple0x0(t *testing.T) {
 func TestBoard_AddSpaceshipOnCoordsSimple13x15(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 
 	spaceship, err := SpaceshipFromPattern([]string{
@@ -39,7 +39,7 @@ func TestBoard_AddSpaceshipOnCoordsSimple13x15(t *testing.T) {
 func TestBoard_AddSpaceshipOnCoordsSimpleVert15x13(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 
 	spaceship, err := SpaceshipFromPattern([]string{
@@ -56,7 +56,7 @@ func TestBoard_AddSpaceshipOnCoordsSimpleVert15x13(t *testing.T) {
 func TestBoard_AddSpaceshipOnCoordsInvalidSimple14x15(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 
 	spaceship, err := SpaceshipFromPattern([]string{
@@ -71,7 +71,7 @@ func TestBoard_AddSpaceshipOnCoordsInvalidSimple14x15(t *testing.T) {
 func TestBoard_AddSpaceshipOnCoordsInvalidSimpleVert15x14(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 
 	spaceship, err := SpaceshipFromPattern([]string{
@@ -88,7 +88,7 @@ func TestBoard_AddSpaceshipOnCoordsInvalidSimpleVert15x14(t *testing.T) {
 func TestBoard_AddSpaceshipOnCoordsInvalidOverlap(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 
 	spaceship1, err := SpaceshipFromPattern([]string{
@@ -113,7 +113,7 @@ func TestBoard_AddSpaceshipOnCoordsInvalidOverlap(t *testing.T) {
 func TestBoard_AddSpaceshipOnCoordsInvalidOverlap3X0(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 
 	spaceship1, err := SpaceshipFromPattern([]string{
@@ -138,7 +138,7 @@ func TestBoard_AddSpaceshipOnCoordsInvalidOverlap3X0(t *testing.T) {
 func TestBoard_AddSpaceshipOnCoordsInvalidOverlapWingerB(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 
 	spaceship1, err := SpaceshipFromPattern(SpaceshipPatternWinger)
@@ -153,3 +153,80 @@ func TestBoard_AddSpaceshipOnCoordsInvalidOverlapWingerB(t *testing.T) {
 	err = board.AddSpaceshipOnCoords(spaceship2.CopyWithOffset(9, 10))
 	assert.Error(err)
 }
+
+func TestBoard_AddSpaceshipOnCoordsNoTouch(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	rules.NoTouch = true
+
+	board, err := NewBlankSelfBoard(rules)
+	assert.NoError(err)
+
+	spaceship, err := SpaceshipFromPattern([]string{
+		"***",
+	})
+	assert.NoError(err)
+
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(1, 1))
+	assert.NoError(err)
+
+	// edge-to-edge
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(4, 1))
+	assert.Error(err)
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(1, 2))
+	assert.Error(err)
+
+	// diagonally
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(4, 2))
+	assert.Error(err)
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(0, 0).CopyWithRotate(90))
+	assert.Error(err)
+
+	// with a gap is fine
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(5, 1))
+	assert.NoError(err)
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(1, 3))
+	assert.NoError(err)
+}
+
+func TestBoard_AddSpaceshipOnCoordsTouch(t *testing.T) {
+	assert := require.New(t)
+
+	board, err := NewBlankSelfBoard(DefaultRules())
+	assert.NoError(err)
+
+	spaceship, err := SpaceshipFromPattern([]string{
+		"***",
+	})
+	assert.NoError(err)
+
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(1, 1))
+	assert.NoError(err)
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(4, 1))
+	assert.NoError(err)
+	err = board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(2, 2))
+	assert.NoError(err)
+}
+
+func TestNewRandomBoardNoTouch(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	rules.NoTouch = true
+
+	rng := testRand()
+	for i := 0; i < 10; i++ {
+		board, err := NewRandomSelfBoard(rules, rules.Fleet.Patterns(), nil, rng)
+		assert.NoError(err)
+
+		for _, spaceship := range board.Spaceships() {
+			for _, coords := range spaceship.coords {
+				for _, neighbour := range coords.neighbours() {
+					other := board.spaceshipOn(neighbour)
+					assert.True(other == nil || other == spaceship)
+				}
+			}
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 func TestBoardFromPatternEmpty(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 	assert.NotNil(board)
 	assert.Equal(0, board.CountHits())
@@ -20,7 +20,7 @@ func TestBoardFromPatternEmpty(t *testing.T) {
 func TestBoardFromPatternWithMarks(t *testing.T) {
 	assert := require.New(t)
 
-	board := &BaseBoard{}
+	board := newBaseBoard(DefaultRules())
 	err := FillBoardFromPattern(board, []string{
 		"X-*.............",
 		"................",
@@ -46,7 +46,7 @@ func TestBoardFromPatternWithMarks(t *testing.T) {
 func TestBoardFromPatternInvalidRows(t *testing.T) {
 	assert := require.New(t)
 
-	board1 := &BaseBoard{}
+	board1 := newBaseBoard(DefaultRules())
 	err1 := FillBoardFromPattern(board1, []string{
 		"................",
 		"................",
@@ -66,7 +66,7 @@ func TestBoardFromPatternInvalidRows(t *testing.T) {
 	})
 	assert.Error(err1)
 
-	board2 := &BaseBoard{}
+	board2 := newBaseBoard(DefaultRules())
 	err2 := FillBoardFromPattern(board2, []string{
 		"................",
 		"................",
@@ -113,12 +113,12 @@ func TestBoardFromPatternInvalidCols(t *testing.T) {
 	}
 
 	for row := 0; row < 16; row++ {
-		pattern := BlankBoardPattern()
+		pattern := BlankBoardPattern(DefaultRules())
 
 		for _, invalidRow := range invalidRows {
 			pattern[row] = invalidRow
 
-			board := &BaseBoard{}
+			board := newBaseBoard(DefaultRules())
 			err := FillBoardFromPattern(board, pattern)
 			assert.Error(err)
 		}
@@ -136,12 +136,12 @@ func TestBoardFromPatternInvalidChars(t *testing.T) {
 	}
 
 	for row := 0; row < 16; row++ {
-		pattern := BlankBoardPattern()
+		pattern := BlankBoardPattern(DefaultRules())
 
 		for _, invalidRow := range invalidRows {
 			pattern[row] = invalidRow
 
-			board := &BaseBoard{}
+			board := newBaseBoard(DefaultRules())
 			err := FillBoardFromPattern(board, pattern)
 			assert.Error(err)
 		}
@@ -151,7 +151,7 @@ func TestBoardFromPatternInvalidChars(t *testing.T) {
 func TestBoardFromPatternHits(t *testing.T) {
 	assert := require.New(t)
 
-	board := &BaseBoard{}
+	board := newBaseBoard(DefaultRules())
 	err := FillBoardFromPattern(board, []string{
 		"X...............",
 		".X..............",
@@ -179,7 +179,7 @@ func TestBoardFromPatternHits(t *testing.T) {
 func TestBoardFromPatternMisses(t *testing.T) {
 	assert := require.New(t)
 
-	board := &BaseBoard{}
+	board := newBaseBoard(DefaultRules())
 	err := FillBoardFromPattern(board, []string{
 		"-...............",
 		".-..............",
@@ -207,7 +207,7 @@ func TestBoardFromPatternMisses(t *testing.T) {
 func TestBoardFromPatternMixed(t *testing.T) {
 	assert := require.New(t)
 
-	board := &BaseBoard{}
+	board := newBaseBoard(DefaultRules())
 	err := FillBoardFromPattern(board, []string{
 		"X...............",
 		".-..............",
@@ -7,7 +7,7 @@ import (
 )
 
 func NewBasicTestBoardWithSpaceship(assert *require.Assertions) *SelfBoard {
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 
 	spaceship, err := SpaceshipFromPattern([]string{
@@ -77,6 +77,28 @@ func TestBoard_ApplyShotKill(t *testing.T) {
 	assert.Equal(ShotStatusKill, res.ShotStatus)
 }
 
+func TestBoard_ApplyShotKillSunk(t *testing.T) {
+	assert := require.New(t)
+
+	board := NewBasicTestBoardWithSpaceship(assert)
+
+	board.ApplyShot(&Coords{0, 0})
+	board.ApplyShot(&Coords{1, 0})
+	assert.Equal("XX*.............", board.ToPattern()[0])
+
+	// all the cells of the spaceship are sunk when it's killed
+	res := board.ApplyShot(&Coords{2, 0})
+	assert.Equal(ShotStatusKill, res.ShotStatus)
+	assert.Equal("###.............", board.ToPattern()[0])
+	assert.Equal(3, board.CountHits())
+
+	// a shot on a sunk cell doesn't change anything
+	res = board.ApplyShot(&Coords{0, 0})
+	assert.Equal(ShotStatusMiss, res.ShotStatus)
+	assert.Equal("###.............", board.ToPattern()[0])
+	assert.Equal(0, board.CountMisses())
+}
+
 func TestBoard_ReceiveSalvoKill(t *testing.T) {
 	assert := require.New(t)
 
@@ -118,7 +140,7 @@ func TestBoard_ApplyShotKillTwice(t *testing.T) {
 func TestBoard_ApplyShotStatus(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankOpponentBoard(1)
+	board, err := NewBlankOpponentBoard(DefaultRules(), 1)
 	assert.NoError(err)
 
 	board.ApplyShotStatus(&Coords{2, 0}, ShotStatusHit)
@@ -149,7 +171,7 @@ func TestBoard_ApplyShotStatus(t *testing.T) {
 	board.ApplyShotStatus(&Coords{1, 0}, ShotStatusKill)
 
 	assert.Equal([]string{
-		".XX-............",
+		".#X-............",
 		"................",
 		"................",
 		"................",
@@ -174,13 +196,13 @@ func TestBoard_ApplyShotStatus(t *testing.T) {
 func TestBoard_ApplyShotStatusKill(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankOpponentBoard(1)
+	board, err := NewBlankOpponentBoard(DefaultRules(), 1)
 	assert.NoError(err)
 
 	board.ApplyShotStatus(&Coords{2, 0}, ShotStatusKill)
 
 	assert.Equal([]string{
-		"..X.............",
+		"..#.............",
 		"................",
 		"................",
 		"................",
@@ -201,6 +223,12 @@ func TestBoard_ApplyShotStatusKill(t *testing.T) {
 	assert.Equal(uint8(0), board.spaceshipsAlive)
 	assert.Equal(0, board.CountShipsAlive())
 	assert.Equal(true, board.AllShipsDead())
+
+	// a kill on the sunk cell again, or anywhere else when nothing is alive, doesn't kill another spaceship
+	board.ApplyShotStatus(&Coords{2, 0}, ShotStatusKill)
+	board.ApplyShotStatus(&Coords{4, 0}, ShotStatusKill)
+	assert.Equal(uint8(0), board.spaceshipsAlive)
+	assert.Equal("..#.............", board.ToPattern()[0])
 }
 
 func TestBoard_ApplyShotOutOfBounds(t *testing.T) {
@@ -216,7 +244,7 @@ func TestBoard_ApplyShotOutOfBounds(t *testing.T) {
 func TestBoard_ApplyShotStatusOutOfBounds(t *testing.T) {
 	assert := require.New(t)
 
-	board, err := NewBlankOpponentBoard(1)
+	board, err := NewBlankOpponentBoard(DefaultRules(), 1)
 	assert.NoError(err)
 
 	board.ApplyShotStatus(&Coords{20, 20}, ShotStatusKill)
@@ -240,3 +268,187 @@ func TestBoard_ApplyShotStatusOutOfBounds(t *testing.T) {
 		"................",
 	}, board.ToPattern())
 }
+
+func TestBoard_ApplyShotStatusKillSunk(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  5,
+		Height: 5,
+		Fleet: Fleet{
+			{Name: "Angle", Pattern: []string{"*", "**"}, Count: 1},
+			{Name: "Line", Pattern: []string{"***"}, Count: 2},
+		},
+	}
+
+	board, err := NewBlankOpponentBoard(rules, 3)
+	assert.NoError(err)
+
+	// only the line fits on the kill and our hits, so it's the spaceship we killed
+	board.ApplyShotStatus(&Coords{0, 0}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{1, 0}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{2, 0}, ShotStatusKill)
+	assert.Equal([]string{"Line"}, board.Killed())
+
+	assert.Equal([]string{
+		"###..",
+		".....",
+		".....",
+		".....",
+		".....",
+	}, board.ToPattern())
+	assert.Equal(3, board.CountHits())
+	assert.Equal(6, board.CountCellsAlive())
+
+	// both a line and the angle fit on the kill and our hits, only the cells they have in common are surely sunk
+	board.ApplyShotStatus(&Coords{1, 2}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{2, 2}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{2, 3}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{3, 2}, ShotStatusKill)
+
+	assert.Equal([]string{
+		"###..",
+		".....",
+		".X##.",
+		"..X..",
+		".....",
+	}, board.ToPattern())
+	assert.Equal(7, board.CountHits())
+	assert.Equal(1, board.CountShipsAlive())
+}
+
+func TestBoard_ApplyShotStatusKillAlive(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  5,
+		Height: 5,
+		Fleet: Fleet{
+			{Name: "Angle", Pattern: []string{"*", "**"}, Count: 1},
+			{Name: "Line", Pattern: []string{"***"}, Count: 2},
+		},
+	}
+
+	board, err := NewBlankOpponentBoard(rules, 3)
+	assert.NoError(err)
+
+	// only the angle fits on the kill and our hits, so we know we killed the angle
+	board.ApplyShotStatus(&Coords{0, 0}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{0, 1}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{1, 1}, ShotStatusKill)
+	assert.Equal([]string{"Angle"}, board.Killed())
+	assert.Equal([]string{"Line", "Line"}, board.FleetAlive())
+
+	// both a line and the angle would fit on the kill and our hits, but the angle is already dead
+	board.ApplyShotStatus(&Coords{1, 3}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{2, 3}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{2, 4}, ShotStatusHit)
+	board.ApplyShotStatus(&Coords{3, 3}, ShotStatusKill)
+
+	assert.Equal([]string{
+		"#....",
+		"##...",
+		".....",
+		".###.",
+		"..X..",
+	}, board.ToPattern())
+	assert.Equal([]string{"Angle", "Line"}, board.Killed())
+	assert.Equal([]string{"Line"}, board.FleetAlive())
+}
+
+func TestBoard_ApplyShotKillName(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  5,
+		Height: 5,
+		Fleet: Fleet{
+			{Name: "Angle", Pattern: []string{"*", "**"}, Count: 1},
+			{Name: "Line", Pattern: []string{"***"}, Count: 2},
+		},
+	}
+
+	// the spaceships get their name from the fleet
+	board, err := NewRandomSelfBoard(rules, rules.Fleet.Patterns(), nil, testRand())
+	assert.NoError(err)
+	assert.Equal([]string{"Angle", "Line", "Line"}, board.FleetAlive())
+
+	// a kill tells which spaceship was killed
+	killed := board.Spaceships()[1]
+	res := board.ReceiveSalvo(killed.Coords())
+	assert.Equal(ShotStatusKill, res[len(res)-1].ShotStatus)
+	assert.Equal("Line", res[len(res)-1].Spaceship)
+	for _, shotRes := range res[:len(res)-1] {
+		assert.Equal("", shotRes.Spaceship)
+	}
+
+	assert.Equal([]string{"Angle", "Line"}, board.FleetAlive())
+}
+
+func TestBoard_ApplyShotResultName(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  5,
+		Height: 5,
+		Fleet: Fleet{
+			{Name: "Angle", Pattern: []string{"*", "**"}, Count: 1},
+			{Name: "Line", Pattern: []string{"***"}, Count: 2},
+		},
+	}
+
+	board, err := NewBlankOpponentBoard(rules, 3)
+	assert.NoError(err)
+	assert.Equal([]string{"Angle", "Line", "Line"}, board.FleetAlive())
+
+	// both a line and the angle fit on the kill and our hits, but we're told it's the angle
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{1, 2}, ShotStatus: ShotStatusHit})
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{2, 2}, ShotStatus: ShotStatusHit})
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{2, 3}, ShotStatus: ShotStatusHit})
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{3, 2}, ShotStatus: ShotStatusKill, Spaceship: "Angle"})
+
+	assert.Equal([]string{
+		".....",
+		".....",
+		".X##.",
+		"..#..",
+		".....",
+	}, board.ToPattern())
+	assert.Equal([]string{"Line", "Line"}, board.FleetAlive())
+
+	// when we're not told which spaceship we killed we don't know which are alive
+	board.ApplyShotStatus(&Coords{0, 0}, ShotStatusKill)
+	assert.Nil(board.FleetAlive())
+}
+
+func TestBoard_ApplyShotResultWrongName(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  5,
+		Height: 5,
+		Fleet: Fleet{
+			{Name: "Angle", Pattern: []string{"*", "**"}, Count: 1},
+			{Name: "Line", Pattern: []string{"***"}, Count: 2},
+		},
+	}
+
+	board, err := NewBlankOpponentBoard(rules, 3)
+	assert.NoError(err)
+
+	// a name that's not part of the fleet is ignored, only a line fits so that's what we killed
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{0, 0}, ShotStatus: ShotStatusHit})
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{1, 0}, ShotStatus: ShotStatusHit})
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{2, 0}, ShotStatus: ShotStatusKill, Spaceship: "Titanic"})
+	assert.Equal([]string{"Line"}, board.Killed())
+	assert.Equal([]string{"Angle", "Line"}, board.FleetAlive())
+
+	// and so is the name of a spaceship that's already dead
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{1, 2}, ShotStatus: ShotStatusHit})
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{1, 3}, ShotStatus: ShotStatusHit})
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{2, 3}, ShotStatus: ShotStatusKill, Spaceship: "Angle"})
+	board.ApplyShotResult(&ShotResult{Coords: &Coords{4, 2}, ShotStatus: ShotStatusKill, Spaceship: "Angle"})
+	assert.Equal([]string{"Line", "Angle"}, board.Killed())
+	assert.Nil(board.FleetAlive())
+	assert.Equal(0, board.CountShipsAlive())
+}
@@ -10,10 +10,15 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// a random source with a fixed seed so the "randomness" is consistent
+func testRand() *rand.Rand {
+	return rand.New(NewSeededRandomSource(1))
+}
+
 func TestNewRandomBoard(t *testing.T) {
 	assert := require.New(t)
 
-	_, err := NewRandomSelfBoard(SpaceshipsSetForBaseGame)
+	_, err := NewRandomSelfBoard(DefaultRules(), SpaceshipsSetForBaseGame, nil, testRand())
 	assert.NoError(err)
 }
 
@@ -61,6 +66,7 @@ func TestCoordsState_String(t *testing.T) {
 	assert.Equal("X", fmt.Sprintf("%s", CoordsHit))
 	assert.Equal("-", fmt.Sprintf("%s", CoordsMiss))
 	assert.Equal("*", fmt.Sprintf("%s", CoordsShip))
+	assert.Equal("#", fmt.Sprintf("%s", CoordsSunk))
 }
 
 func TestCoordsGroupFromSalvoStrings(t *testing.T) {
@@ -82,9 +88,6 @@ func TestCoordsGroupFromSalvoStringsInvalid(t *testing.T) {
 func TestNewRandomBoardTooMany1(t *testing.T) {
 	assert := require.New(t)
 
-	// fresh seed so the "randomness" is consistent and results in 2 fails and then a success
-	rand.Seed(10)
-
 	ManySpaceships := [][]string{
 		SpaceshipPatternSClass,
 		SpaceshipPatternSClass,
@@ -107,27 +110,19 @@ func TestNewRandomBoardTooMany1(t *testing.T) {
 		SpaceshipPatternSClass,
 	}
 
-	// first board should fail with this seed
-	board, err := newRandomSelfBoard(ManySpaceships)
-	assert.NoError(err)
-	assert.Nil(board)
-
-	// second board should also fail with this seed
-	board, err = newRandomSelfBoard(ManySpaceships)
-	assert.NoError(err)
-	assert.Nil(board)
-
-	// third board should pass with this seed
-	board, err = newRandomSelfBoard(ManySpaceships)
-	assert.NoError(err)
-	assert.NotNil(board)
+	// a crowded board never needs a retry, every seed results in a board
+	for seed := int64(1); seed <= 5; seed++ {
+		board, err := NewRandomSelfBoard(DefaultRules(), ManySpaceships, nil, rand.New(NewSeededRandomSource(seed)))
+		assert.NoError(err)
+		assert.Equal(len(ManySpaceships), len(board.Spaceships()))
+	}
 }
 
 func TestNewRandomBoardTooMany2(t *testing.T) {
 	assert := require.New(t)
 
-	// fresh seed so the "randomness" is consistent and results in 2 fails and then a success
-	rand.Seed(10)
+	// fresh seed so the "randomness" is consistent
+	rng := rand.New(NewSeededRandomSource(10))
 
 	ManySpaceships := [][]string{
 		SpaceshipPatternSClass,
@@ -151,7 +146,110 @@ func TestNewRandomBoardTooMany2(t *testing.T) {
 		SpaceshipPatternSClass,
 	}
 
-	board, err := NewRandomSelfBoard(ManySpaceships)
+	board, err := NewRandomSelfBoard(DefaultRules(), ManySpaceships, nil, rng)
 	assert.NoError(err)
 	assert.NotNil(board)
 }
+
+func TestNewRandomBoardImpossible(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  3,
+		Height: 3,
+		Salvo:  &ShipsAliveSalvoRule{},
+	}
+
+	// 2 squares of 2x2 are less cells than the board has, but they can't both fit on it
+	_, err := NewRandomSelfBoard(rules, [][]string{{"**", "**"}, {"**", "**"}}, nil, testRand())
+	assert.Error(err)
+	assert.Contains(err.Error(), "there's no way to fit the spaceships")
+
+	// 3 lines do fit, but not without touching
+	_, err = NewRandomSelfBoard(rules, [][]string{{"***"}, {"***"}, {"***"}}, nil, testRand())
+	assert.NoError(err)
+	rules.NoTouch = true
+	_, err = NewRandomSelfBoard(rules, [][]string{{"***"}, {"***"}, {"***"}}, nil, testRand())
+	assert.Error(err)
+}
+
+func TestBoard_AddSpaceshipUniform(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  3,
+		Height: 3,
+		Salvo:  &ShipsAliveSalvoRule{},
+	}
+
+	spaceship, err := SpaceshipFromPattern([]string{"**"})
+	assert.NoError(err)
+
+	// a 2 cell line can be placed in 12 ways on a 3x3 board, every way should come up about as often
+	rng := testRand()
+	counts := make(map[string]int)
+	for i := 0; i < 12000; i++ {
+		board, err := NewBlankSelfBoard(rules)
+		assert.NoError(err)
+		assert.NoError(board.AddSpaceship(spaceship, rng))
+
+		counts[board.String()]++
+	}
+
+	assert.Equal(12, len(counts))
+	for _, count := range counts {
+		assert.InDelta(1000, count, 150)
+	}
+
+	// when there's no room left it errors
+	board, err := NewBlankSelfBoard(rules)
+	assert.NoError(err)
+	line, err := SpaceshipFromPattern([]string{"***"})
+	assert.NoError(err)
+	assert.NoError(board.AddSpaceshipOnCoords(line.CopyWithOffset(0, 1)))
+	assert.NoError(board.AddSpaceship(spaceship, rng))
+	rules.NoTouch = true
+	assert.Error(board.AddSpaceship(spaceship, rng))
+}
+
+func TestNewRandomBoardUniform(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:   4,
+		Height:  4,
+		Salvo:   &ShipsAliveSalvoRule{},
+		NoTouch: true,
+	}
+
+	spaceships := [][]string{{"**"}, {"**"}}
+
+	// count the layouts, the 2 lines are the same so every pair of positions that don't touch is 1 layout
+	spaceship, err := SpaceshipFromPattern(spaceships[0])
+	assert.NoError(err)
+	positions := spaceshipPositions(rules, spaceship)
+	layouts := 0
+	for i, a := range positions {
+		for _, b := range positions[i+1:] {
+			if !a.blocks.Intersects(b.cells) {
+				layouts++
+			}
+		}
+	}
+
+	// every layout should come up about as often,
+	//  picking the positions 1 spaceship at a time makes some layouts more than twice as likely as others
+	rng := testRand()
+	counts := make(map[string]int)
+	for i := 0; i < layouts*300; i++ {
+		board, err := NewRandomSelfBoard(rules, spaceships, nil, rng)
+		assert.NoError(err)
+
+		counts[board.String()]++
+	}
+
+	assert.Equal(layouts, len(counts))
+	for _, count := range counts {
+		assert.InDelta(300, count, 75)
+	}
+}
@@ -30,7 +30,7 @@ func TestBoard_ToPatternBoard(t *testing.T) {
 		"...............-",
 	}
 
-	board := NewSelfBoard()
+	board := NewSelfBoard(DefaultRules())
 	err := FillBoardFromPattern(board.BaseBoard, pattern)
 	assert.NoError(err)
 	assert.NotNil(board)
@@ -64,14 +64,14 @@ func TestBoardToPatternWithShipsNoMarks(t *testing.T) {
 		"..**............",
 	}
 
-	board, err := NewBlankSelfBoard()
+	board, err := NewBlankSelfBoard(DefaultRules())
 	assert.NoError(err)
 	assert.NotNil(board)
 	assert.Equal(0, board.CountHits())
 	assert.Equal(0, board.CountMisses())
 	assert.Equal(0, len(board.spaceships))
 
-	assert.Equal(BlankBoardPattern(), board.ToPattern())
+	assert.Equal(BlankBoardPattern(DefaultRules()), board.ToPattern())
 
 	winger, _ := SpaceshipFromPattern(SpaceshipPatternWinger)
 	angle, _ := SpaceshipFromPattern(SpaceshipPatternAngle)
@@ -129,7 +129,7 @@ func TestBoardToPatternWithShipsAndMarks(t *testing.T) {
 		"..**...........-",
 	}
 
-	board := NewSelfBoard()
+	board := NewSelfBoard(DefaultRules())
 	err := FillBoardFromPattern(board.BaseBoard, pattern)
 	assert.NoError(err)
 	assert.NotNil(board)
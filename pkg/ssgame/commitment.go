@@ -0,0 +1,138 @@
+package ssgame
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+)
+
+// the result of checking the board our opponent revealed at the end of the game
+type Verdict int8
+
+const (
+	VerdictPending      Verdict = 0
+	VerdictHonest       Verdict = 1
+	VerdictCheat        Verdict = 2
+	VerdictUnverifiable Verdict = 3
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case VerdictPending:
+		return "pending"
+	case VerdictHonest:
+		return "honest"
+	case VerdictCheat:
+		return "cheat"
+	case VerdictUnverifiable:
+		return "unverifiable"
+	}
+
+	panic("Unreachable")
+}
+
+// a commitment to the pattern of our board, the hash is send to our opponent before the game starts
+//  so that at the end of the game he can check we didn't move our spaceships or lie about his shots
+//  the salt makes sure our opponent can't brute force the pattern from the hash, we keep it to ourselves until we reveal
+//  the hash is the merkle root over the cells of the board, so we can prove the state of a single cell for every shot
+type BoardCommitment struct {
+	Hash    string   `json:"hash"`
+	Salt    string   `json:"salt"`
+	Pattern []string `json:"pattern"`
+	tree    *boardMerkleTree
+}
+
+// commit to the pattern of a board with a random salt
+func CommitToBoard(board *SelfBoard) (*BoardCommitment, error) {
+	salt := make([]byte, 32)
+	_, err := cryptorand.Read(salt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to commit to board")
+	}
+
+	commitment := &BoardCommitment{
+		Salt:    hex.EncodeToString(salt),
+		Pattern: board.ToPattern(),
+	}
+	commitment.tree = newBoardMerkleTree(board.rules, commitment.Pattern, commitment.Salt)
+	commitment.Hash = commitment.tree.root()
+
+	return commitment, nil
+}
+
+// the proof of the state of a cell of our board, to go with the result of a shot
+func (c *BoardCommitment) Prove(rules *Rules, coords *Coords) *CellProof {
+	if c.tree == nil {
+		c.tree = newBoardMerkleTree(rules, c.Pattern, c.Salt)
+	}
+
+	return c.tree.prove(coords)
+}
+
+// check the board our opponent revealed at the end of the game
+//  it should match the commitment he gave us, it should be a valid board for the rules
+//  and when we replay our salvos on it they should have the same results as our opponent reported to us
+//  when the spaceships touch there can be more than 1 way to split the pattern into spaceships, any of them is fine
+func VerifyRevealedBoard(rules *Rules, commitment string, pattern []string, salt string, salvos [][]*ShotResult) error {
+	// this also makes sure the pattern has the right dimensions before we build a merkle tree from it
+	solver, err := newPatternSolverFromPattern(rules, pattern)
+	if err != nil {
+		return errors.Wrapf(err, "Revealed board is invalid")
+	}
+
+	if BoardMerkleRoot(rules, pattern, salt) != commitment {
+		return errors.New("Revealed board does not match the commitment")
+	}
+
+	valid := false
+	honest := solver.solve(func(spaceships []*Spaceship) bool {
+		valid = true
+
+		board, err := newSelfBoardFromSpaceships(rules, spaceships)
+		if err != nil {
+			return false
+		}
+
+		return replaySalvos(board, salvos)
+	})
+
+	if !valid {
+		return errors.New("Revealed board does not match the fleet")
+	}
+	if honest == nil {
+		return errors.New("Revealed board does not match the results of our salvos")
+	}
+
+	return nil
+}
+
+// replay salvos on a board and check that every shot has the same result as was reported
+//  when a salvo contains the same coords twice only the last result counts, that's all that was reported
+//  when the name of a killed spaceship was reported it should be the name of the spaceship on the board too
+func replaySalvos(board *SelfBoard, salvos [][]*ShotResult) bool {
+	for _, salvo := range salvos {
+		coords := make(CoordsGroup, len(salvo))
+		reported := make(map[Coords]*ShotResult, len(salvo))
+		for i, shotRes := range salvo {
+			coords[i] = shotRes.Coords
+			reported[*shotRes.Coords] = shotRes
+		}
+
+		replayed := make(map[Coords]*ShotResult, len(salvo))
+		for _, shotRes := range board.ReceiveSalvo(coords) {
+			replayed[*shotRes.Coords] = shotRes
+		}
+
+		for coords, shotRes := range reported {
+			if replayed[coords].ShotStatus != shotRes.ShotStatus {
+				return false
+			}
+			if shotRes.Spaceship != "" && replayed[coords].Spaceship != shotRes.Spaceship {
+				return false
+			}
+		}
+	}
+
+	return true
+}
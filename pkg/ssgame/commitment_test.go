@@ -0,0 +1,151 @@
+package ssgame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitToBoard(t *testing.T) {
+	assert := require.New(t)
+
+	board, err := NewRandomSelfBoard(DefaultRules(), SpaceshipsSetForBaseGame, nil, testRand())
+	assert.NoError(err)
+
+	commitment1, err := CommitToBoard(board)
+	assert.NoError(err)
+	commitment2, err := CommitToBoard(board)
+	assert.NoError(err)
+
+	assert.Equal(board.ToPattern(), commitment1.Pattern)
+	assert.Equal(BoardMerkleRoot(DefaultRules(), commitment1.Pattern, commitment1.Salt), commitment1.Hash)
+
+	// the salt makes every commitment different
+	assert.NotEqual(commitment1.Salt, commitment2.Salt)
+	assert.NotEqual(commitment1.Hash, commitment2.Hash)
+}
+
+func TestVerifyRevealedBoard(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  4,
+		Height: 4,
+		Fleet: Fleet{
+			{Name: "Angle", Pattern: []string{"*", "**"}, Count: 1},
+			{Name: "Line", Pattern: []string{"***"}, Count: 1},
+		},
+		Salvo: &ShipsAliveSalvoRule{},
+	}
+
+	pattern := []string{
+		"*...",
+		"**..",
+		"....",
+		".***",
+	}
+	salt := "c0ffee"
+	commitment := BoardMerkleRoot(rules, pattern, salt)
+
+	salvos := [][]*ShotResult{
+		{
+			{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+			{Coords: mustCoordsFromString("3x0"), ShotStatus: ShotStatusMiss},
+		},
+		{
+			{Coords: mustCoordsFromString("0x1"), ShotStatus: ShotStatusHit},
+			{Coords: mustCoordsFromString("1x1"), ShotStatus: ShotStatusKill},
+		},
+	}
+
+	assert.NoError(VerifyRevealedBoard(rules, commitment, pattern, salt, salvos))
+
+	// wrong salt
+	assert.Error(VerifyRevealedBoard(rules, commitment, pattern, "c0ffef", salvos))
+
+	// lied about a miss
+	lied := [][]*ShotResult{
+		{
+			{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusMiss},
+		},
+	}
+	assert.Error(VerifyRevealedBoard(rules, commitment, pattern, salt, lied))
+
+	// lied about a kill
+	lied = [][]*ShotResult{
+		{
+			{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+			{Coords: mustCoordsFromString("0x1"), ShotStatus: ShotStatusHit},
+			{Coords: mustCoordsFromString("1x1"), ShotStatus: ShotStatusHit},
+		},
+	}
+	assert.Error(VerifyRevealedBoard(rules, commitment, pattern, salt, lied))
+
+	// the name of the spaceship that was killed should match the board
+	named := [][]*ShotResult{
+		{
+			{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+			{Coords: mustCoordsFromString("0x1"), ShotStatus: ShotStatusHit},
+			{Coords: mustCoordsFromString("1x1"), ShotStatus: ShotStatusKill, Spaceship: "Angle"},
+		},
+	}
+	assert.NoError(VerifyRevealedBoard(rules, commitment, pattern, salt, named))
+
+	named[0][2].Spaceship = "Line"
+	assert.Error(VerifyRevealedBoard(rules, commitment, pattern, salt, named))
+
+	// a board that doesn't match the fleet
+	invalid := []string{
+		"*...",
+		"**..",
+		"....",
+		"..**",
+	}
+	assert.Error(VerifyRevealedBoard(rules, BoardMerkleRoot(rules, invalid, salt), invalid, salt, nil))
+}
+
+func TestVerifyRevealedBoardTouching(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  4,
+		Height: 2,
+		Fleet: Fleet{
+			{Name: "Line", Pattern: []string{"**"}, Count: 2},
+		},
+		Salvo: &ShipsAliveSalvoRule{},
+	}
+
+	// the 2 spaceships could be split up horizontally or vertically
+	pattern := []string{
+		"**..",
+		"**..",
+	}
+	salt := "c0ffee"
+	commitment := BoardMerkleRoot(rules, pattern, salt)
+
+	// with vertical spaceships
+	assert.NoError(VerifyRevealedBoard(rules, commitment, pattern, salt, [][]*ShotResult{
+		{
+			{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+			{Coords: mustCoordsFromString("0x1"), ShotStatus: ShotStatusKill},
+		},
+	}))
+
+	// with horizontal spaceships
+	assert.NoError(VerifyRevealedBoard(rules, commitment, pattern, salt, [][]*ShotResult{
+		{
+			{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+			{Coords: mustCoordsFromString("1x0"), ShotStatus: ShotStatusKill},
+		},
+	}))
+
+	// but it can't be both
+	assert.Error(VerifyRevealedBoard(rules, commitment, pattern, salt, [][]*ShotResult{
+		{
+			{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+			{Coords: mustCoordsFromString("0x1"), ShotStatus: ShotStatusKill},
+			{Coords: mustCoordsFromString("1x0"), ShotStatus: ShotStatusKill},
+		},
+	}))
+}
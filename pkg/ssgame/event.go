@@ -0,0 +1,201 @@
+package ssgame
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// the type of change to a game an Event describes
+type EventType string
+
+const (
+	EventGameCreated    EventType = "game_created"
+	EventBoardPlaced    EventType = "board_placed"
+	EventOpponentReady  EventType = "opponent_ready"
+	EventSalvoFired     EventType = "salvo_fired"
+	EventSalvoReceived  EventType = "salvo_received"
+	EventGameWon        EventType = "game_won"
+	EventCheatDetected  EventType = "cheat_detected"
+	EventBoardsRevealed EventType = "boards_revealed"
+	EventRevealFailed   EventType = "reveal_failed"
+)
+
+// a change to a game, every change is appended to the log of the game so the game can be rebuild from it with Replay
+//  only the fields for the type of event are set, anything random (like our board) is in the event
+//  so that replaying the log always gives the same game
+//  Game and Board are snapshots (as JSON) because the board and game keep changing after the event
+type Event struct {
+	Type               EventType        `json:"type"`
+	Time               time.Time        `json:"time"`
+	Game               json.RawMessage  `json:"game,omitempty"`
+	Board              json.RawMessage  `json:"board,omitempty"`
+	Commitment         *BoardCommitment `json:"commitment,omitempty"`
+	OpponentCommitment string           `json:"opponent_commitment,omitempty"`
+	Salvo              CoordsGroup      `json:"salvo,omitempty"`
+	Results            []*ShotResult    `json:"results,omitempty"`
+	Player             WhichPlayer      `json:"player,omitempty"`
+	Reason             string           `json:"reason,omitempty"`
+	Pattern            []string         `json:"pattern,omitempty"`
+	Salt               string           `json:"salt,omitempty"`
+}
+
+// rebuild a game from it's log, the first event should be the creation of the game
+func Replay(events []*Event) (*Game, error) {
+	if len(events) == 0 || events[0] == nil || events[0].Type != EventGameCreated {
+		return nil, errors.New("Failed to replay game: log does not start with the creation of the game")
+	}
+
+	game := &Game{}
+	err := json.Unmarshal(events[0].Game, game)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to replay game")
+	}
+	game.Events = []*Event{events[0]}
+
+	for i, event := range events[1:] {
+		if event == nil {
+			return nil, errors.Errorf("Failed to replay game: empty event %d", i+1)
+		}
+
+		err := game.apply(event)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to replay game: event %d", i+1)
+		}
+
+		game.Events = append(game.Events, event)
+	}
+
+	return game, nil
+}
+
+// apply the event to the game and append it to the log
+func (g *Game) record(event *Event) error {
+	event.Time = time.Now().UTC()
+
+	err := g.apply(event)
+	if err != nil {
+		return err
+	}
+
+	g.Events = append(g.Events, event)
+
+	return nil
+}
+
+// apply the change the event describes to the game, this is the only place where an event changes a game
+//  so the game is always the same whether the event just happened or is replayed
+func (g *Game) apply(event *Event) error {
+	switch event.Type {
+	case EventGameCreated:
+		return errors.New("Game is already created")
+
+	case EventBoardPlaced:
+		board := &SelfBoard{}
+		err := json.Unmarshal(event.Board, board)
+		if err != nil {
+			return err
+		}
+		board.rules = g.Rules
+
+		g.SelfBoard = board
+		g.SelfCommitment = event.Commitment
+		g.SelfReady = true
+		g.maybeStart()
+
+	case EventOpponentReady:
+		g.OpponentCommitment = event.OpponentCommitment
+		g.OpponentReady = true
+		g.maybeStart()
+
+	case EventSalvoReceived:
+		// the results follow from our board, they're only in the log to see what happened
+		event.Results = g.SelfBoard.ReceiveSalvo(event.Salvo)
+		g.EndTurn(PlayerOpponent, event.Results)
+
+	case EventSalvoFired:
+		// only results for exactly the coords we fired are accepted, a coords we fired twice is only applied once
+		salvoRes, err := OrderSalvoResults(event.Salvo, event.Results)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to apply salvo results")
+		}
+
+		applied := make(map[Coords]bool, len(salvoRes))
+		for _, shotRes := range salvoRes {
+			if !applied[*shotRes.Coords] {
+				applied[*shotRes.Coords] = true
+				g.OpponentBoard.ApplyShotResult(shotRes)
+			}
+		}
+		g.RecordSelfSalvo(salvoRes)
+		g.EndTurn(PlayerSelf, salvoRes)
+
+	case EventGameWon:
+		g.Status = GameStatusDone
+		g.PlayerWon = event.Player
+
+	case EventCheatDetected:
+		g.Status = GameStatusDone
+		g.PlayerWon = PlayerSelf
+		g.Verdict = VerdictCheat
+		g.CheatReason = event.Reason
+
+	case EventBoardsRevealed:
+		if g.OpponentCommitment == "" {
+			g.Verdict = VerdictUnverifiable
+			return nil
+		}
+
+		err := VerifyRevealedBoard(g.Rules, g.OpponentCommitment, event.Pattern, event.Salt, g.SelfSalvos)
+		if err != nil {
+			g.Verdict = VerdictCheat
+			g.CheatReason = err.Error()
+		} else {
+			g.Verdict = VerdictHonest
+		}
+
+	case EventRevealFailed:
+		g.Verdict = VerdictUnverifiable
+
+	default:
+		return errors.Errorf("Unknown event type: %s", event.Type)
+	}
+
+	return nil
+}
+
+// the number of turns that have been played, every salvo fired by either player is a turn
+func (g *Game) Turns() int {
+	turns := 0
+	for _, event := range g.Events {
+		if event.Type == EventSalvoFired || event.Type == EventSalvoReceived {
+			turns++
+		}
+	}
+
+	return turns
+}
+
+// the game as it was after a turn, turn 0 is the game before the first salvo was fired
+//  anything that happened as a result of the salvo (like winning the game) is part of the turn
+func (g *Game) AtTurn(turn int) (*Game, error) {
+	if turn < 0 || turn > g.Turns() {
+		return nil, errors.Errorf("Failed to replay game: turn should be between 0 and %d", g.Turns())
+	}
+
+	end := len(g.Events)
+	turns := 0
+	for i, event := range g.Events {
+		if event.Type == EventSalvoFired || event.Type == EventSalvoReceived {
+			if turns == turn {
+				end = i
+				break
+			}
+
+			turns++
+		}
+	}
+
+	return Replay(g.Events[:end])
+}
@@ -0,0 +1,238 @@
+package ssgame
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGame_Events(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	rules.ManualPlacement = true
+
+	game, err := InitNewGame("game-1", &Player{
+		PlayerID: "player-1",
+		FullName: "Player 1",
+	}, rules, nil, NewSeededRandomSource(1), PlayerOpponent)
+	assert.NoError(err)
+	assert.NoError(game.RecordCreated())
+
+	// the log can only be started once
+	assert.Error(game.RecordCreated())
+
+	board, err := NewRandomSelfBoard(rules, rules.Fleet.Patterns(), nil, testRand())
+	assert.NoError(err)
+	assert.NoError(game.PlaceSelfBoard(board))
+	assert.NoError(game.SetOpponentReady())
+	assert.Equal(GameStatusOnGoing, game.Status)
+
+	// our opponent kills the first spaceship
+	salvoRes, err := game.ReceiveSalvo(game.SelfBoard.Spaceships()[0].coords)
+	assert.NoError(err)
+	assert.Equal(ShotStatusKill, salvoRes[len(salvoRes)-1].ShotStatus)
+	assert.Equal(PlayerSelf, game.PlayerTurn)
+
+	// results have to be for exactly the coords we fired, otherwise nothing is applied
+	assert.Error(game.ApplySalvoResults(CoordsGroup{mustCoordsFromString("0x0"), mustCoordsFromString("1x0")}, []*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill},
+	}))
+	assert.Error(game.ApplySalvoResults(CoordsGroup{mustCoordsFromString("0x0")}, []*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusMiss},
+		{Coords: mustCoordsFromString("1x0"), ShotStatus: ShotStatusKill},
+	}))
+	assert.Equal(5, game.OpponentBoard.CountShipsAlive())
+	assert.Equal(0, len(game.SelfSalvos))
+	assert.Equal(PlayerSelf, game.PlayerTurn)
+
+	// we fire the same coords twice and get a kill and a miss
+	salvo := CoordsGroup{mustCoordsFromString("0x0"), mustCoordsFromString("1x0"), mustCoordsFromString("0x0")}
+	assert.NoError(game.ApplySalvoResults(salvo, []*ShotResult{
+		{Coords: mustCoordsFromString("1x0"), ShotStatus: ShotStatusMiss},
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill},
+	}))
+	assert.Equal(4, game.OpponentBoard.CountShipsAlive())
+	assert.Equal(3, len(game.SelfSalvos[0]))
+	assert.Equal(PlayerOpponent, game.PlayerTurn)
+
+	assert.NoError(game.Win(PlayerSelf))
+	assert.Equal(GameStatusDone, game.Status)
+
+	verdict, err := game.VerifyOpponentBoard(nil, "")
+	assert.NoError(err)
+	assert.Equal(VerdictUnverifiable, verdict)
+
+	types := make([]EventType, len(game.Events))
+	for i, event := range game.Events {
+		types[i] = event.Type
+		assert.False(event.Time.IsZero())
+	}
+	assert.Equal([]EventType{
+		EventGameCreated,
+		EventBoardPlaced,
+		EventOpponentReady,
+		EventSalvoReceived,
+		EventSalvoFired,
+		EventGameWon,
+		EventBoardsRevealed,
+	}, types)
+
+	// the log is saved with the game and replaying it gives the exact same game
+	data, err := json.Marshal(game)
+	assert.NoError(err)
+
+	loaded := &Game{}
+	assert.NoError(json.Unmarshal(data, loaded))
+
+	replayed, err := Replay(loaded.Events)
+	assert.NoError(err)
+
+	replayedData, err := json.Marshal(replayed)
+	assert.NoError(err)
+	assert.Equal(string(data), string(replayedData))
+
+	// replaying part of the log gives the game as it was at that point
+	replayed, err = Replay(loaded.Events[:4])
+	assert.NoError(err)
+	assert.Equal(GameStatusOnGoing, replayed.Status)
+	assert.Equal(PlayerSelf, replayed.PlayerTurn)
+	assert.Equal(4, replayed.SelfBoard.CountShipsAlive())
+	assert.Equal(5, replayed.OpponentBoard.CountShipsAlive())
+}
+
+func TestGame_AtTurn(t *testing.T) {
+	assert := require.New(t)
+
+	game, err := InitNewGame("game-1", &Player{
+		PlayerID: "player-1",
+		FullName: "Player 1",
+	}, DefaultRules(), nil, NewSeededRandomSource(1), PlayerOpponent)
+	assert.NoError(err)
+	assert.NoError(game.RecordCreated())
+	assert.Equal(0, game.Turns())
+
+	_, err = game.ReceiveSalvo(game.SelfBoard.Spaceships()[0].coords)
+	assert.NoError(err)
+	assert.NoError(game.ApplySalvoResults(CoordsGroup{mustCoordsFromString("0x0")}, []*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+	}))
+	assert.NoError(game.Win(PlayerSelf))
+	assert.Equal(2, game.Turns())
+
+	past, err := game.AtTurn(0)
+	assert.NoError(err)
+	assert.Equal(0, past.Turns())
+	assert.Equal(5, past.SelfBoard.CountShipsAlive())
+	assert.Equal(PlayerOpponent, past.PlayerTurn)
+
+	past, err = game.AtTurn(1)
+	assert.NoError(err)
+	assert.Equal(1, past.Turns())
+	assert.Equal(4, past.SelfBoard.CountShipsAlive())
+	assert.Equal(0, past.OpponentBoard.CountHits())
+	assert.Equal(PlayerSelf, past.PlayerTurn)
+
+	// winning is part of the last turn
+	past, err = game.AtTurn(2)
+	assert.NoError(err)
+	assert.Equal(1, past.OpponentBoard.CountHits())
+	assert.Equal(GameStatusDone, past.Status)
+
+	_, err = game.AtTurn(3)
+	assert.Error(err)
+	_, err = game.AtTurn(-1)
+	assert.Error(err)
+
+	// the game itself is untouched
+	assert.Equal(2, game.Turns())
+	assert.Equal(4, game.SelfBoard.CountShipsAlive())
+}
+
+func TestReplayInvalid(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := Replay(nil)
+	assert.Error(err)
+
+	_, err = Replay([]*Event{{Type: EventGameWon, Player: PlayerSelf}})
+	assert.Error(err)
+
+	game, err := CreateNewGame("game-1", &Player{
+		PlayerID: "player-1",
+		FullName: "Player 1",
+	}, DefaultRules(), nil, NewSeededRandomSource(1), true)
+	assert.NoError(err)
+	assert.NoError(game.RecordCreated())
+
+	_, err = Replay(append(game.Events, &Event{Type: "bogus"}))
+	assert.Error(err)
+
+	_, err = Replay(append(game.Events, game.Events[0]))
+	assert.Error(err)
+}
+
+func TestGame_RevealFailed(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  4,
+		Height: 4,
+		Fleet: Fleet{
+			{Name: "Angle", Pattern: []string{"*", "**"}, Count: 1},
+			{Name: "Line", Pattern: []string{"***"}, Count: 1},
+		},
+		Salvo: &ShipsAliveSalvoRule{},
+	}
+
+	pattern := []string{
+		"*...",
+		"**..",
+		"....",
+		".***",
+	}
+	salt := "c0ffee"
+
+	game, err := InitNewGame("game-1", &Player{
+		PlayerID: "player-1",
+		FullName: "Player 1",
+	}, rules, nil, NewSeededRandomSource(1), PlayerOpponent)
+	assert.NoError(err)
+	assert.NoError(game.SetOpponentCommitment(BoardMerkleRoot(rules, pattern, salt)))
+	assert.NoError(game.RecordCreated())
+
+	// there's nothing to reveal before the game is done
+	assert.Error(game.RevealFailed("no reveal"))
+
+	_, err = game.ReceiveSalvo(CoordsGroup{mustCoordsFromString("0x0")})
+	assert.NoError(err)
+	assert.NoError(game.ApplySalvoResults(CoordsGroup{mustCoordsFromString("0x0")}, []*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+	}))
+	assert.NoError(game.Win(PlayerSelf))
+
+	// our opponent didn't reveal his board, so we can't tell if he was honest
+	assert.NoError(game.RevealFailed("no reveal"))
+	assert.Equal(VerdictUnverifiable, game.Verdict)
+	assert.NoError(game.RevealFailed("no reveal again"))
+	assert.Equal(EventRevealFailed, game.Events[len(game.Events)-1].Type)
+	assert.Equal("no reveal", game.Events[len(game.Events)-1].Reason)
+
+	// until he reveals it after all
+	verdict, err := game.VerifyOpponentBoard(pattern, salt)
+	assert.NoError(err)
+	assert.Equal(VerdictHonest, verdict)
+
+	// a failed reveal afterwards doesn't change the verdict
+	assert.NoError(game.RevealFailed("no reveal"))
+	assert.Equal(VerdictHonest, game.Verdict)
+
+	replayed, err := Replay(game.Events)
+	assert.NoError(err)
+	assert.Equal(VerdictHonest, replayed.Verdict)
+
+	replayed, err = Replay(game.Events[:len(game.Events)-1])
+	assert.NoError(err)
+	assert.Equal(VerdictUnverifiable, replayed.Verdict)
+}
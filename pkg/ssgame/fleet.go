@@ -0,0 +1,180 @@
+package ssgame
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// the max number of spaceships in a fleet, we count the spaceships alive in a uint8
+const MaxFleetSize = 255
+
+// a type of spaceship in a fleet and how many of them the fleet has
+type FleetSpaceship struct {
+	Name    string
+	Pattern []string
+	Count   int
+}
+
+// the spaceships that each player gets to place on his board
+type Fleet []*FleetSpaceship
+
+// the fleet for a standard game
+func BaseGameFleet() Fleet {
+	return Fleet{
+		{Name: "Winger", Pattern: SpaceshipPatternWinger, Count: 1},
+		{Name: "Angle", Pattern: SpaceshipPatternAngle, Count: 1},
+		{Name: "A-Class", Pattern: SpaceshipPatternAClass, Count: 1},
+		{Name: "B-Class", Pattern: SpaceshipPatternBClass, Count: 1},
+		{Name: "S-Class", Pattern: SpaceshipPatternSClass, Count: 1},
+	}
+}
+
+// sanity check the fleet, every pattern should be a valid spaceship
+func (f Fleet) Validate() error {
+	if len(f) == 0 {
+		return errors.New("Invalid fleet: no spaceships")
+	}
+
+	names := make(map[string]bool, len(f))
+	for _, fleetSpaceship := range f {
+		if fleetSpaceship == nil {
+			return errors.New("Invalid fleet: empty spaceship")
+		}
+		if fleetSpaceship.Name == "" {
+			return errors.New("Invalid fleet: spaceship without name")
+		}
+		if names[fleetSpaceship.Name] {
+			return errors.Errorf("Invalid fleet: duplicate spaceship name [%s]", fleetSpaceship.Name)
+		}
+		names[fleetSpaceship.Name] = true
+
+		if fleetSpaceship.Count < 1 {
+			return errors.Errorf("Invalid fleet: spaceship [%s] should have a count of at least 1", fleetSpaceship.Name)
+		}
+
+		_, err := SpaceshipFromPattern(fleetSpaceship.Pattern)
+		if err != nil {
+			return errors.Wrapf(err, "Invalid fleet: spaceship [%s]", fleetSpaceship.Name)
+		}
+	}
+
+	if f.Size() > MaxFleetSize {
+		return errors.Errorf("Invalid fleet: more than %d spaceships", MaxFleetSize)
+	}
+
+	return nil
+}
+
+// the total number of spaceships in the fleet
+func (f Fleet) Size() int {
+	size := 0
+	for _, fleetSpaceship := range f {
+		size += fleetSpaceship.Count
+	}
+
+	return size
+}
+
+// the number of spaceships in the fleet with the name, 0 when it's not part of the fleet
+func (f Fleet) count(name string) int {
+	for _, fleetSpaceship := range f {
+		if fleetSpaceship.Name == name {
+			return fleetSpaceship.Count
+		}
+	}
+
+	return 0
+}
+
+// the total number of cells covered by all the spaceships in the fleet
+func (f Fleet) Cells() int {
+	cells := 0
+	for _, fleetSpaceship := range f {
+		spaceship, err := SpaceshipFromPattern(fleetSpaceship.Pattern)
+		if err != nil {
+			continue
+		}
+
+		cells += len(spaceship.coords) * fleetSpaceship.Count
+	}
+
+	return cells
+}
+
+// the patterns of all the spaceships in the fleet, a pattern is repeated as many times as its count
+func (f Fleet) Patterns() [][]string {
+	patterns := make([][]string, 0, f.Size())
+	for _, fleetSpaceship := range f {
+		for i := 0; i < fleetSpaceship.Count; i++ {
+			patterns = append(patterns, fleetSpaceship.Pattern)
+		}
+	}
+
+	return patterns
+}
+
+// the names of all the spaceships in the fleet by their pattern (the rows joined with newlines),
+//  a name is repeated as many times as its count
+func (f Fleet) namesByPattern() map[string][]string {
+	names := make(map[string][]string, len(f))
+	for _, fleetSpaceship := range f {
+		key := strings.Join(fleetSpaceship.Pattern, "\n")
+		for i := 0; i < fleetSpaceship.Count; i++ {
+			names[key] = append(names[key], fleetSpaceship.Name)
+		}
+	}
+
+	return names
+}
+
+// the format of a file with fleets, eg;
+//  {"fleets": {"halloween": [{"name": "Pumpkin", "count": 2, "pattern": [".*.", "***"]}]}}
+type fleetsFileJSON struct {
+	Fleets map[string][]*fleetSpaceshipJSON `json:"fleets"`
+}
+
+type fleetSpaceshipJSON struct {
+	Name    string   `json:"name"`
+	Pattern []string `json:"pattern"`
+	Count   int      `json:"count"`
+}
+
+// parse the named fleets from the contents of a fleets file, every fleet is validated
+func FleetsFromJSON(data []byte) (map[string]Fleet, error) {
+	fleetsFile := &fleetsFileJSON{}
+	err := json.Unmarshal(data, fleetsFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse fleets")
+	}
+
+	if len(fleetsFile.Fleets) == 0 {
+		return nil, errors.New("Failed to parse fleets: no fleets defined")
+	}
+
+	fleets := make(map[string]Fleet, len(fleetsFile.Fleets))
+	for name, fleetJSON := range fleetsFile.Fleets {
+		fleet := make(Fleet, len(fleetJSON))
+		for i, fleetSpaceship := range fleetJSON {
+			if fleetSpaceship == nil {
+				return nil, errors.Errorf("Failed to parse fleet [%s]: empty spaceship", name)
+			}
+
+			fleet[i] = &FleetSpaceship{
+				Name:    fleetSpaceship.Name,
+				Pattern: fleetSpaceship.Pattern,
+				Count:   fleetSpaceship.Count,
+			}
+		}
+
+		err := fleet.Validate()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to parse fleet [%s]", name)
+		}
+
+		fleets[name] = fleet
+	}
+
+	return fleets, nil
+}
@@ -0,0 +1,100 @@
+package ssgame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseGameFleet(t *testing.T) {
+	assert := require.New(t)
+
+	fleet := BaseGameFleet()
+	assert.NoError(fleet.Validate())
+	assert.Equal(5, fleet.Size())
+	assert.Equal(SpaceshipsSetForBaseGame, fleet.Patterns())
+}
+
+func TestFleet_Patterns(t *testing.T) {
+	assert := require.New(t)
+
+	fleet := Fleet{
+		{Name: "Dot", Pattern: []string{"*"}, Count: 2},
+		{Name: "Line", Pattern: []string{"***"}, Count: 1},
+	}
+
+	assert.NoError(fleet.Validate())
+	assert.Equal(3, fleet.Size())
+	assert.Equal([][]string{{"*"}, {"*"}, {"***"}}, fleet.Patterns())
+}
+
+func TestFleet_ValidateInvalid(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Error(Fleet{}.Validate())
+	assert.Error(Fleet{nil}.Validate())
+	assert.Error(Fleet{{Name: "", Pattern: []string{"*"}, Count: 1}}.Validate())
+	assert.Error(Fleet{{Name: "Dot", Pattern: []string{"*"}, Count: 0}}.Validate())
+	assert.Error(Fleet{{Name: "Dot", Pattern: []string{"*A"}, Count: 1}}.Validate())
+	assert.Error(Fleet{{Name: "Dot", Pattern: []string{"..."}, Count: 1}}.Validate())
+	assert.Error(Fleet{{Name: "Dot", Pattern: []string{"*"}, Count: 200}, {Name: "Dot2", Pattern: []string{"*"}, Count: 200}}.Validate())
+	assert.Error(Fleet{
+		{Name: "Dot", Pattern: []string{"*"}, Count: 1},
+		{Name: "Dot", Pattern: []string{"**"}, Count: 1},
+	}.Validate())
+}
+
+func TestRules_ValidateFleet(t *testing.T) {
+	assert := require.New(t)
+
+	// a winger is 3x5 so it fits on a 5x3 board when rotated
+	assert.NoError((&Rules{Width: 5, Height: 3, Fleet: Fleet{{Name: "Winger", Pattern: SpaceshipPatternWinger, Count: 1}}, Salvo: &ShipsAliveSalvoRule{}}).Validate())
+	assert.Error((&Rules{Width: 4, Height: 3, Fleet: Fleet{{Name: "Winger", Pattern: SpaceshipPatternWinger, Count: 1}}}).Validate())
+
+	// 4 cells don't fit on a 3x1 board
+	assert.Error((&Rules{Width: 3, Height: 1, Fleet: Fleet{{Name: "Dot", Pattern: []string{"*"}, Count: 4}}}).Validate())
+	assert.Error((&Rules{Width: 10, Height: 10}).Validate())
+}
+
+func TestFleetsFromJSON(t *testing.T) {
+	assert := require.New(t)
+
+	fleets, err := FleetsFromJSON([]byte(`{
+		"fleets": {
+			"halloween": [
+				{"name": "Pumpkin", "count": 2, "pattern": [".*.", "***"]},
+				{"name": "Broom", "count": 1, "pattern": ["****", ".*.*"]}
+			],
+			"tiny": [
+				{"name": "Dot", "count": 5, "pattern": ["*"]}
+			]
+		}
+	}`))
+	assert.NoError(err)
+	assert.Equal(2, len(fleets))
+
+	assert.Equal(Fleet{
+		{Name: "Pumpkin", Pattern: []string{".*.", "***"}, Count: 2},
+		{Name: "Broom", Pattern: []string{"****", ".*.*"}, Count: 1},
+	}, fleets["halloween"])
+	assert.Equal(5, fleets["tiny"].Size())
+}
+
+func TestFleetsFromJSONInvalid(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := FleetsFromJSON([]byte(`{`))
+	assert.Error(err)
+
+	_, err = FleetsFromJSON([]byte(`{"fleets": {}}`))
+	assert.Error(err)
+
+	_, err = FleetsFromJSON([]byte(`{"fleets": {"empty": []}}`))
+	assert.Error(err)
+
+	_, err = FleetsFromJSON([]byte(`{"fleets": {"null": [null]}}`))
+	assert.Error(err)
+
+	_, err = FleetsFromJSON([]byte(`{"fleets": {"bad": [{"name": "Bad", "count": 1, "pattern": ["*X*"]}]}}`))
+	assert.Error(err)
+}
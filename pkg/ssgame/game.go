@@ -1,18 +1,13 @@
 package ssgame
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
-)
-
-func init() {
-	// give us a "random seed"
-	rand.Seed(1)
+	"time"
 
-	// this would be the proper way
-	//  but for serious things (eg a casino game, crypto, etc) crypto/rand should be used instead of math/rand
-	// rand.Seed(time.Now().UTC().UnixNano())
-}
+	"github.com/pkg/errors"
+)
 
 // define the status a game can have as a type
 type GameStatus int8
@@ -20,6 +15,7 @@ type GameStatus int8
 const (
 	GameStatusOnGoing GameStatus = 0
 	GameStatusDone    GameStatus = 1
+	GameStatusPlacing GameStatus = 2
 )
 
 // the type we use to indicate which player's turn it is or which player won etc
@@ -46,66 +42,90 @@ const (
 
 // the type we use to store a player (both self and opponent)
 type Player struct {
-	PlayerID     string
-	FullName     string
-	ProtocolHost string
-	ProtocolPort int
+	PlayerID     string `json:"player_id"`
+	FullName     string `json:"full_name"`
+	ProtocolHost string `json:"protocol_host"`
+	ProtocolPort int    `json:"protocol_port"`
 }
 
 // the type to hold a game between 2 players
+//  every game has it's own random source so games don't share (or leak) their random state
+//  SelfSalvos are the salvos we fired with the results our opponent reported, to verify his board at the end of the game
+//  Events is the log of every change to the game since it was created, see Replay
+//  NamedKills is when our opponent wants to know the name of the spaceship he killed with the results of his salvos
 type Game struct {
-	GameID        string
-	Opponent      *Player
-	Status        GameStatus
-	SelfBoard     *SelfBoard
-	OpponentBoard *OpponentBoard
-	PlayerTurn    WhichPlayer
-	PlayerWon     WhichPlayer
+	GameID             string
+	Opponent           *Player
+	Rules              *Rules
+	Status             GameStatus
+	SelfBoard          *SelfBoard
+	OpponentBoard      *OpponentBoard
+	PlayerTurn         WhichPlayer
+	PlayerWon          WhichPlayer
+	SelfReady          bool
+	OpponentReady      bool
+	SelfCommitment     *BoardCommitment
+	OpponentCommitment string
+	SelfSalvos         [][]*ShotResult
+	Verdict            Verdict
+	CheatReason        string
+	Events             []*Event
+	NamedKills         bool
+	rng                *rand.Rand
 }
 
 // create a new game with a random board for self and a blank board for opponent
-func CreateNewGame(gameID string, opponent *Player, cheatToBeFirst bool) (*Game, error) {
-	// give ourselves a random board
-	selfBoard, err := NewRandomSelfBoard(SpaceshipsSetForBaseGame)
-	if err != nil {
-		return nil, err
-	}
-
-	// give our opponent a blank board
-	opponentBoard, err := NewBlankOpponentBoard(uint8(len(SpaceshipsSetForBaseGame)))
+//  when source is nil a new crypto seeded source is used, when placement is nil every position for a spaceship is as likely
+func CreateNewGame(gameID string, opponent *Player, rules *Rules, placement PlacementStrategy, source rand.Source, cheatToBeFirst bool) (*Game, error) {
+	game, err := newGame(gameID, opponent, rules, placement, source)
 	if err != nil {
 		return nil, err
 	}
 
 	// determine which player get's to go first
-	firstPlayer := PlayerSelf
+	game.PlayerTurn = PlayerSelf
 	if !cheatToBeFirst {
-		firstPlayer = RandomFirstPlayer()
+		game.PlayerTurn = RandomFirstPlayer(game.rng)
 	}
 
-	game := &Game{
-		GameID:        gameID,
-		Opponent:      opponent,
-		Status:        GameStatusOnGoing,
-		SelfBoard:     selfBoard,
-		OpponentBoard: opponentBoard,
-		PlayerTurn:    firstPlayer,
-		PlayerWon:     PlayerNone,
+	return game, nil
+}
+
+// init a new game that we were challanged to play
+//  when source is nil a new crypto seeded source is used, when placement is nil every position for a spaceship is as likely
+func InitNewGame(gameID string, opponent *Player, rules *Rules, placement PlacementStrategy, source rand.Source, firstPlayer WhichPlayer) (*Game, error) {
+	game, err := newGame(gameID, opponent, rules, placement, source)
+	if err != nil {
+		return nil, err
 	}
 
+	game.PlayerTurn = firstPlayer
+
 	return game, nil
 }
 
-// init a new game that we were challanged to play
-func InitNewGame(gameID string, opponent *Player, firstPlayer WhichPlayer) (*Game, error) {
-	// give ourselves a random board
-	selfBoard, err := NewRandomSelfBoard(SpaceshipsSetForBaseGame)
+// create the game, when the rules say the spaceships are placed manually then self gets a blank board
+//  and the game won't start until both players are ready
+func newGame(gameID string, opponent *Player, rules *Rules, placement PlacementStrategy, source rand.Source) (*Game, error) {
+	if source == nil {
+		source = NewRandomSource()
+	}
+	rng := rand.New(source)
+
+	var selfBoard *SelfBoard
+	var err error
+	if rules.ManualPlacement {
+		selfBoard, err = NewBlankSelfBoard(rules)
+	} else {
+		// give ourselves a random board
+		selfBoard, err = NewRandomSelfBoard(rules, rules.Fleet.Patterns(), placement, rng)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	// give our opponent a blank board
-	opponentBoard, err := NewBlankOpponentBoard(uint8(len(SpaceshipsSetForBaseGame)))
+	opponentBoard, err := NewBlankOpponentBoard(rules, uint8(rules.Fleet.Size()))
 	if err != nil {
 		return nil, err
 	}
@@ -113,16 +133,356 @@ func InitNewGame(gameID string, opponent *Player, firstPlayer WhichPlayer) (*Gam
 	game := &Game{
 		GameID:        gameID,
 		Opponent:      opponent,
+		Rules:         rules,
 		Status:        GameStatusOnGoing,
 		SelfBoard:     selfBoard,
 		OpponentBoard: opponentBoard,
-		PlayerTurn:    firstPlayer,
+		PlayerTurn:    PlayerNone,
 		PlayerWon:     PlayerNone,
+		SelfReady:     true,
+		OpponentReady: true,
+		Verdict:       VerdictPending,
+		rng:           rng,
+	}
+
+	if rules.ManualPlacement {
+		game.Status = GameStatusPlacing
+		game.SelfReady = false
+		game.OpponentReady = false
+	} else {
+		game.SelfCommitment, err = CommitToBoard(selfBoard)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return game, nil
 }
 
+// the random source of the game, anything random for the game should be drawn from this
+func (g *Game) Rand() *rand.Rand {
+	return g.rng
+}
+
+// start the log of the game with a snapshot of the game,
+//  this should be done once everything about the game is known (like the game ID and who's first) and before anything else happens
+func (g *Game) RecordCreated() error {
+	if len(g.Events) != 0 {
+		return errors.New("Failed to record creation of game, game already has a log")
+	}
+
+	snapshot, err := json.Marshal(g)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to record creation of game")
+	}
+
+	g.Events = []*Event{{
+		Type: EventGameCreated,
+		Time: time.Now().UTC(),
+		Game: snapshot,
+	}}
+
+	return nil
+}
+
+// replace our (blank) board with the board the player has placed his spaceships on
+func (g *Game) PlaceSelfBoard(board *SelfBoard) error {
+	if g.Status != GameStatusPlacing {
+		return errors.New("Failed to place spaceships, game is not in placing status")
+	}
+	if g.SelfReady {
+		return errors.New("Failed to place spaceships, spaceships are already placed")
+	}
+
+	commitment, err := CommitToBoard(board)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := json.Marshal(board)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to place spaceships")
+	}
+
+	return g.record(&Event{
+		Type:       EventBoardPlaced,
+		Board:      snapshot,
+		Commitment: commitment,
+	})
+}
+
+// mark that our opponent has placed his spaceships
+func (g *Game) SetOpponentReady() error {
+	if g.Status != GameStatusPlacing {
+		return errors.New("Failed to mark opponent ready, game is not in placing status")
+	}
+
+	return g.record(&Event{
+		Type:               EventOpponentReady,
+		OpponentCommitment: g.OpponentCommitment,
+	})
+}
+
+// start the game once both players are ready
+func (g *Game) maybeStart() {
+	if g.Status == GameStatusPlacing && g.SelfReady && g.OpponentReady {
+		g.Status = GameStatusOnGoing
+	}
+}
+
+// hand the turn over to the other player after the shooter fired a salvo,
+//  unless the rules let the shooter keep the turn because he hit something
+func (g *Game) EndTurn(shooter WhichPlayer, salvoRes []*ShotResult) {
+	if g.Rules.ChainOnHit {
+		for _, shotRes := range salvoRes {
+			if shotRes.ShotStatus == ShotStatusHit || shotRes.ShotStatus == ShotStatusKill {
+				g.PlayerTurn = shooter
+				return
+			}
+		}
+	}
+
+	switch shooter {
+	case PlayerSelf:
+		g.PlayerTurn = PlayerOpponent
+	case PlayerOpponent:
+		g.PlayerTurn = PlayerSelf
+	}
+}
+
+// store the commitment our opponent made to his board, he only gets to do this once
+func (g *Game) SetOpponentCommitment(commitment string) error {
+	if g.OpponentCommitment != "" && g.OpponentCommitment != commitment {
+		return errors.New("Failed to set commitment, opponent already committed to a board")
+	}
+
+	g.OpponentCommitment = commitment
+
+	return nil
+}
+
+// keep track of the salvos we fired and the results our opponent reported for them
+func (g *Game) RecordSelfSalvo(salvoRes []*ShotResult) {
+	g.SelfSalvos = append(g.SelfSalvos, salvoRes)
+}
+
+// our opponent fired a salvo at our board, when it killed our last spaceship our opponent has won
+func (g *Game) ReceiveSalvo(salvo CoordsGroup) ([]*ShotResult, error) {
+	event := &Event{
+		Type:  EventSalvoReceived,
+		Salvo: salvo,
+	}
+
+	err := g.record(event)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.SelfBoard.AllShipsDead() {
+		err = g.Win(PlayerOpponent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return event.Results, nil
+}
+
+// apply the results our opponent reported for the salvo we fired to his board,
+//  salvo are the coords in the order we fired them and salvoRes has to have a result for exactly those coords
+func (g *Game) ApplySalvoResults(salvo CoordsGroup, salvoRes []*ShotResult) error {
+	return g.record(&Event{
+		Type:    EventSalvoFired,
+		Salvo:   salvo,
+		Results: salvoRes,
+	})
+}
+
+// end the game with a winner
+func (g *Game) Win(player WhichPlayer) error {
+	return g.record(&Event{
+		Type:   EventGameWon,
+		Player: player,
+	})
+}
+
+// the results of a salvo in the order the shots were fired, our opponent reports the results per coords
+//  so when the salvo contains the same coords twice they get the same result twice
+//  the results have to be for exactly the coords we fired, otherwise our opponent is hiding or making up shots
+func OrderSalvoResults(salvo CoordsGroup, salvoRes []*ShotResult) ([]*ShotResult, error) {
+	fired := make(map[Coords]bool, len(salvo))
+	for _, coords := range salvo {
+		fired[*coords] = true
+	}
+
+	reported := make(map[Coords]*ShotResult, len(salvoRes))
+	for _, shotRes := range salvoRes {
+		if !fired[*shotRes.Coords] {
+			return nil, errors.Errorf("Opponent reported a result for %s which wasn't fired", shotRes.Coords)
+		}
+
+		if prev, ok := reported[*shotRes.Coords]; ok && (prev.ShotStatus != shotRes.ShotStatus || prev.Spaceship != shotRes.Spaceship) {
+			return nil, errors.Errorf("Opponent reported different results for %s", shotRes.Coords)
+		}
+
+		reported[*shotRes.Coords] = shotRes
+	}
+
+	res := make([]*ShotResult, 0, len(salvo))
+	for _, coords := range salvo {
+		shotRes, ok := reported[*coords]
+		if !ok {
+			return nil, errors.Errorf("Opponent reported no result for %s", coords)
+		}
+
+		res = append(res, &ShotResult{Coords: coords, ShotStatus: shotRes.ShotStatus, Spaceship: shotRes.Spaceship})
+	}
+
+	return res, nil
+}
+
+// check the board our opponent revealed at the end of the game against his commitment and the salvos we fired
+//  when our opponent didn't commit to a board (eg he doesn't support it) then there's nothing we can check
+//  a reveal that failed before doesn't stop us from checking the board when our opponent reveals it after all
+func (g *Game) VerifyOpponentBoard(pattern []string, salt string) (Verdict, error) {
+	if g.Status != GameStatusDone {
+		return VerdictPending, errors.New("Failed to verify opponent board, game is not done")
+	}
+	if g.Verdict != VerdictPending && !(g.Verdict == VerdictUnverifiable && g.OpponentCommitment != "" && pattern != nil) {
+		return g.Verdict, nil
+	}
+
+	err := g.record(&Event{
+		Type:    EventBoardsRevealed,
+		Pattern: pattern,
+		Salt:    salt,
+	})
+	if err != nil {
+		return VerdictPending, err
+	}
+
+	return g.Verdict, nil
+}
+
+// our opponent didn't reveal his board at the end of the game, so we can't tell if he was honest
+//  when we already have a verdict there's nothing to change
+func (g *Game) RevealFailed(reason string) error {
+	if g.Status != GameStatusDone {
+		return errors.New("Failed to mark reveal as failed, game is not done")
+	}
+	if g.Verdict != VerdictPending {
+		return nil
+	}
+
+	return g.record(&Event{
+		Type:   EventRevealFailed,
+		Reason: reason,
+	})
+}
+
+// check the proofs our opponent gave with the results of our salvo, this should be done before they're applied to his board
+//  a hit or kill should be proven to be a spaceship cell and a miss to be a blank cell,
+//  except for a miss on a cell we already hit before (or twice in the same salvo), which is a spaceship cell
+//  a shot on a cell we already hit before is always a miss, there can't be more kills than spaceships alive
+//  and a kill can only be of a spaceship of the fleet that's not dead yet,
+//  that doesn't need a proof so it's checked even without a commitment
+//  whether a spaceship was really killed can't be proven per cell, that's checked when our opponent reveals his board
+func (g *Game) VerifySalvoProofs(salvoRes []*ShotResult, proofs map[Coords]*CellProof) error {
+	kills := 0
+	killed := g.OpponentBoard.killedCounts()
+	for _, shotRes := range salvoRes {
+		if shotRes.ShotStatus == ShotStatusMiss || !g.Rules.InBounds(shotRes.Coords) {
+			continue
+		}
+
+		state := g.OpponentBoard.CoordsState(shotRes.Coords)
+		if state == CoordsHit || state == CoordsSunk {
+			return errors.Errorf("Opponent reported a %s on %s but we already hit it", shotRes.ShotStatus, shotRes.Coords)
+		}
+
+		if shotRes.ShotStatus == ShotStatusKill {
+			kills++
+			if kills > g.OpponentBoard.CountShipsAlive() {
+				return errors.Errorf("Opponent reported a kill on %s but he has no spaceships alive left", shotRes.Coords)
+			}
+		}
+
+		if shotRes.ShotStatus == ShotStatusKill && shotRes.Spaceship != "" {
+			count := g.Rules.Fleet.count(shotRes.Spaceship)
+			if count == 0 {
+				return errors.Errorf("Opponent reported a kill of [%s] on %s but it's not part of the fleet", shotRes.Spaceship, shotRes.Coords)
+			}
+
+			killed[shotRes.Spaceship]++
+			if killed[shotRes.Spaceship] > count {
+				return errors.Errorf("Opponent reported a kill of [%s] on %s but all of them are dead already", shotRes.Spaceship, shotRes.Coords)
+			}
+		}
+	}
+
+	// our opponent didn't commit to a board so there's nothing more to verify
+	if g.OpponentCommitment == "" {
+		return nil
+	}
+
+	fired := make(map[Coords]int, len(salvoRes))
+	for _, shotRes := range salvoRes {
+		fired[*shotRes.Coords]++
+	}
+
+	for _, shotRes := range salvoRes {
+		coords := shotRes.Coords
+
+		// shots outside of the board are always a miss
+		if !g.Rules.InBounds(coords) {
+			if shotRes.ShotStatus != ShotStatusMiss {
+				return errors.Errorf("Opponent reported a %s outside of the board on %s", shotRes.ShotStatus, coords)
+			}
+
+			continue
+		}
+
+		proof := proofs[*coords]
+		if proof == nil {
+			return errors.Errorf("Opponent gave no proof for %s", coords)
+		}
+
+		if VerifyCellProof(g.Rules, g.OpponentCommitment, coords, CoordsShip, proof) {
+			state := g.OpponentBoard.CoordsState(coords)
+			alreadyHit := state == CoordsHit || state == CoordsSunk || fired[*coords] > 1
+			if shotRes.ShotStatus == ShotStatusMiss && !alreadyHit {
+				return errors.Errorf("Opponent reported a miss on %s but it's a spaceship", coords)
+			}
+		} else if VerifyCellProof(g.Rules, g.OpponentCommitment, coords, CoordsBlank, proof) {
+			if shotRes.ShotStatus != ShotStatusMiss {
+				return errors.Errorf("Opponent reported a %s on %s but it's blank", shotRes.ShotStatus, coords)
+			}
+		} else {
+			return errors.Errorf("Opponent gave an invalid proof for %s", coords)
+		}
+	}
+
+	return nil
+}
+
+// end the game because our opponent was caught cheating, which means we win
+func (g *Game) AbortCheat(reason string) error {
+	return g.record(&Event{
+		Type:   EventCheatDetected,
+		Reason: reason,
+	})
+}
+
+// the number of shots we get in our next salvo
+func (g *Game) SelfShots() int {
+	return g.Rules.Salvo.Shots(g.SelfBoard)
+}
+
+// the number of shots our opponent gets in his next salvo
+func (g *Game) OpponentShots() int {
+	return g.Rules.Salvo.Shots(g.OpponentBoard)
+}
+
 func (g *Game) String() string {
 	return fmt.Sprintf(
 		"opponent: %s\n"+
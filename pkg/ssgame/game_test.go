@@ -12,7 +12,7 @@ func TestNewGame(t *testing.T) {
 	game, err := CreateNewGame("match-1-1", &Player{
 		PlayerID: "player-1",
 		FullName: "Player 1",
-	}, true)
+	}, DefaultRules(), nil, NewSeededRandomSource(1), true)
 
 	assert.NoError(err)
 	assert.Equal("player-1", game.Opponent.PlayerID)
@@ -30,7 +30,7 @@ func TestInitNewGame(t *testing.T) {
 	game, err := InitNewGame("game-1", &Player{
 		PlayerID: "player-1",
 		FullName: "Player 1",
-	}, PlayerSelf)
+	}, DefaultRules(), nil, NewSeededRandomSource(1), PlayerSelf)
 
 	assert.NoError(err)
 	assert.Equal("player-1", game.Opponent.PlayerID)
@@ -41,3 +41,104 @@ func TestInitNewGame(t *testing.T) {
 	assert.Equal(PlayerSelf, game.PlayerTurn)
 	assert.Equal(PlayerNone, game.PlayerWon)
 }
+
+func TestNewGameManualPlacement(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	rules.ManualPlacement = true
+
+	game, err := CreateNewGame("match-1-1", &Player{
+		PlayerID: "player-1",
+		FullName: "Player 1",
+	}, rules, nil, NewSeededRandomSource(1), true)
+
+	assert.NoError(err)
+	assert.Equal(GameStatusPlacing, game.Status)
+	assert.False(game.SelfReady)
+	assert.False(game.OpponentReady)
+	assert.Equal(0, len(game.SelfBoard.Spaceships()))
+
+	board, err := NewRandomSelfBoard(rules, rules.Fleet.Patterns(), nil, testRand())
+	assert.NoError(err)
+
+	assert.NoError(game.PlaceSelfBoard(board))
+	assert.Equal(GameStatusPlacing, game.Status)
+	assert.True(game.SelfReady)
+	assert.Equal(board, game.SelfBoard)
+
+	// can't place twice
+	assert.Error(game.PlaceSelfBoard(board))
+
+	assert.NoError(game.SetOpponentReady())
+	assert.Equal(GameStatusOnGoing, game.Status)
+
+	// can't mark ready once the game started
+	assert.Error(game.SetOpponentReady())
+}
+
+func TestNewGameNoManualPlacement(t *testing.T) {
+	assert := require.New(t)
+
+	game, err := CreateNewGame("match-1-1", &Player{
+		PlayerID: "player-1",
+		FullName: "Player 1",
+	}, DefaultRules(), nil, NewSeededRandomSource(1), true)
+
+	assert.NoError(err)
+	assert.Equal(GameStatusOnGoing, game.Status)
+	assert.True(game.SelfReady)
+	assert.True(game.OpponentReady)
+
+	board, err := NewBlankSelfBoard(DefaultRules())
+	assert.NoError(err)
+	assert.Error(game.PlaceSelfBoard(board))
+}
+
+func TestGame_EndTurn(t *testing.T) {
+	assert := require.New(t)
+
+	game, err := InitNewGame("match-1", &Player{PlayerID: "player-2"}, DefaultRules(), nil, NewSeededRandomSource(1), PlayerSelf)
+	assert.NoError(err)
+
+	hit := []*ShotResult{{Coords: &Coords{0, 0}, ShotStatus: ShotStatusMiss}, {Coords: &Coords{1, 0}, ShotStatus: ShotStatusHit}}
+	miss := []*ShotResult{{Coords: &Coords{0, 0}, ShotStatus: ShotStatusMiss}}
+
+	// without ChainOnHit the turn always goes to the other player
+	game.EndTurn(PlayerSelf, hit)
+	assert.Equal(PlayerOpponent, game.PlayerTurn)
+	game.EndTurn(PlayerOpponent, hit)
+	assert.Equal(PlayerSelf, game.PlayerTurn)
+
+	// with ChainOnHit the shooter keeps the turn as long as he hits
+	game.Rules.ChainOnHit = true
+	game.EndTurn(PlayerSelf, hit)
+	assert.Equal(PlayerSelf, game.PlayerTurn)
+	game.EndTurn(PlayerSelf, miss)
+	assert.Equal(PlayerOpponent, game.PlayerTurn)
+	game.EndTurn(PlayerOpponent, []*ShotResult{{Coords: &Coords{0, 0}, ShotStatus: ShotStatusKill}})
+	assert.Equal(PlayerOpponent, game.PlayerTurn)
+	game.EndTurn(PlayerOpponent, miss)
+	assert.Equal(PlayerSelf, game.PlayerTurn)
+}
+
+func TestNewGameRandomSource(t *testing.T) {
+	assert := require.New(t)
+
+	opponent := &Player{PlayerID: "player-1"}
+
+	// the same seed gives the same game
+	game1, err := CreateNewGame("match-1-1", opponent, DefaultRules(), nil, NewSeededRandomSource(42), false)
+	assert.NoError(err)
+	game2, err := CreateNewGame("match-1-2", opponent, DefaultRules(), nil, NewSeededRandomSource(42), false)
+	assert.NoError(err)
+	assert.Equal(game1.SelfBoard.ToPattern(), game2.SelfBoard.ToPattern())
+	assert.Equal(game1.PlayerTurn, game2.PlayerTurn)
+
+	// games don't share their random state
+	game3, err := CreateNewGame("match-1-3", opponent, DefaultRules(), nil, nil, false)
+	assert.NoError(err)
+	game4, err := CreateNewGame("match-1-4", opponent, DefaultRules(), nil, nil, false)
+	assert.NoError(err)
+	assert.NotEqual(game3.SelfBoard.ToPattern(), game4.SelfBoard.ToPattern())
+}
@@ -0,0 +1,332 @@
+package ssgame
+
+import (
+	"encoding/json"
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// the JSON formats to save (and load) a game, everything is included except for the random source of the game,
+//  a loaded game gets a new crypto seeded random source
+
+type rulesJSON struct {
+	Width           int                   `json:"width"`
+	Height          int                   `json:"height"`
+	Fleet           []*fleetSpaceshipJSON `json:"fleet"`
+	ManualPlacement bool                  `json:"manual_placement"`
+	NoTouch         bool                  `json:"no_touch"`
+	Salvo           string                `json:"salvo"`
+	SalvoShots      int                   `json:"salvo_shots"`
+	ChainOnHit      bool                  `json:"chain_on_hit"`
+	Mirror          bool                  `json:"mirror"`
+}
+
+func (r *Rules) MarshalJSON() ([]byte, error) {
+	res := &rulesJSON{
+		Width:           r.Width,
+		Height:          r.Height,
+		Fleet:           make([]*fleetSpaceshipJSON, len(r.Fleet)),
+		ManualPlacement: r.ManualPlacement,
+		NoTouch:         r.NoTouch,
+		ChainOnHit:      r.ChainOnHit,
+		Mirror:          r.Mirror,
+	}
+
+	for i, fleetSpaceship := range r.Fleet {
+		res.Fleet[i] = &fleetSpaceshipJSON{
+			Name:    fleetSpaceship.Name,
+			Pattern: fleetSpaceship.Pattern,
+			Count:   fleetSpaceship.Count,
+		}
+	}
+
+	if r.Salvo != nil {
+		res.Salvo = r.Salvo.Name()
+		if fixed, ok := r.Salvo.(*FixedSalvoRule); ok {
+			res.SalvoShots = fixed.N
+		}
+	}
+
+	return json.Marshal(res)
+}
+
+func (r *Rules) UnmarshalJSON(data []byte) error {
+	rJSON := &rulesJSON{}
+	err := json.Unmarshal(data, rJSON)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load rules")
+	}
+
+	salvo, err := SalvoRuleFromName(rJSON.Salvo, rJSON.SalvoShots)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load rules")
+	}
+
+	fleet := make(Fleet, len(rJSON.Fleet))
+	for i, fleetSpaceship := range rJSON.Fleet {
+		if fleetSpaceship == nil {
+			return errors.New("Failed to load rules: empty spaceship in fleet")
+		}
+
+		fleet[i] = &FleetSpaceship{
+			Name:    fleetSpaceship.Name,
+			Pattern: fleetSpaceship.Pattern,
+			Count:   fleetSpaceship.Count,
+		}
+	}
+
+	*r = Rules{
+		Width:           rJSON.Width,
+		Height:          rJSON.Height,
+		Fleet:           fleet,
+		ManualPlacement: rJSON.ManualPlacement,
+		NoTouch:         rJSON.NoTouch,
+		Salvo:           salvo,
+		ChainOnHit:      rJSON.ChainOnHit,
+		Mirror:          rJSON.Mirror,
+	}
+
+	return errors.Wrapf(r.Validate(), "Failed to load rules")
+}
+
+// coords are stored as [x, y], not as a string, because shots outside of the board don't have to be valid coords strings
+func (c *Coords) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int8{c.x, c.y})
+}
+
+func (c *Coords) UnmarshalJSON(data []byte) error {
+	xy := [2]int8{}
+	err := json.Unmarshal(data, &xy)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load coords")
+	}
+
+	c.x, c.y = xy[0], xy[1]
+
+	return nil
+}
+
+type spaceshipJSON struct {
+	Name   string      `json:"name,omitempty"`
+	Coords CoordsGroup `json:"coords"`
+	Hits   CoordsGroup `json:"hits"`
+	Dead   bool        `json:"dead"`
+}
+
+func (s *Spaceship) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&spaceshipJSON{
+		Name:   s.name,
+		Coords: s.coords,
+		Hits:   s.hits,
+		Dead:   s.dead,
+	})
+}
+
+func (s *Spaceship) UnmarshalJSON(data []byte) error {
+	sJSON := &spaceshipJSON{}
+	err := json.Unmarshal(data, sJSON)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load spaceship")
+	}
+
+	if len(sJSON.Coords) == 0 {
+		return errors.New("Failed to load spaceship: blank spaceship")
+	}
+
+	s.name = sJSON.Name
+	s.coords = sJSON.Coords
+	s.hits = sJSON.Hits
+	if s.hits == nil {
+		s.hits = make(CoordsGroup, 0)
+	}
+	s.dead = sJSON.Dead
+
+	return nil
+}
+
+// the grid is stored as a pattern, which cell belongs to which spaceship follows from the coords of the spaceships
+type selfBoardJSON struct {
+	Rules      *Rules       `json:"rules"`
+	Grid       []string     `json:"grid"`
+	Spaceships []*Spaceship `json:"spaceships"`
+}
+
+func (b *SelfBoard) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&selfBoardJSON{
+		Rules:      b.rules,
+		Grid:       b.ToPattern(),
+		Spaceships: b.spaceships,
+	})
+}
+
+func (b *SelfBoard) UnmarshalJSON(data []byte) error {
+	bJSON := &selfBoardJSON{}
+	err := json.Unmarshal(data, bJSON)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load board")
+	}
+
+	if bJSON.Rules == nil {
+		return errors.New("Failed to load board: no rules")
+	}
+
+	board := newBaseBoard(bJSON.Rules)
+	err = FillBoardFromPattern(board, bJSON.Grid)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load board")
+	}
+
+	var spaceshipCells Bitboard
+	for _, spaceship := range bJSON.Spaceships {
+		if spaceship == nil {
+			return errors.New("Failed to load board: empty spaceship")
+		}
+
+		for _, coords := range spaceship.coords {
+			if !board.rules.InBounds(coords) {
+				return errors.Errorf("Failed to load board: spaceship out of bounds on %s", coords)
+			}
+
+			if spaceshipCells.Has(coords) {
+				return errors.Errorf("Failed to load board: spaceships overlap on %s", coords)
+			}
+
+			spaceshipCells.Set(coords)
+		}
+		for _, coords := range spaceship.hits {
+			if !board.rules.InBounds(coords) {
+				return errors.Errorf("Failed to load board: spaceship hit out of bounds on %s", coords)
+			}
+		}
+
+		spaceship.place()
+	}
+
+	b.BaseBoard = board
+	b.spaceships = bJSON.Spaceships
+	b.spaceshipCells = spaceshipCells
+
+	return nil
+}
+
+type opponentBoardJSON struct {
+	Rules           *Rules   `json:"rules"`
+	Grid            []string `json:"grid"`
+	SpaceshipsAlive uint8    `json:"spaceships_alive"`
+	Killed          []string `json:"killed,omitempty"`
+}
+
+func (b *OpponentBoard) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&opponentBoardJSON{
+		Rules:           b.rules,
+		Grid:            b.ToPattern(),
+		SpaceshipsAlive: b.spaceshipsAlive,
+		Killed:          b.killed,
+	})
+}
+
+func (b *OpponentBoard) UnmarshalJSON(data []byte) error {
+	bJSON := &opponentBoardJSON{}
+	err := json.Unmarshal(data, bJSON)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load board")
+	}
+
+	if bJSON.Rules == nil {
+		return errors.New("Failed to load board: no rules")
+	}
+
+	board := newBaseBoard(bJSON.Rules)
+	err = FillBoardFromPattern(board, bJSON.Grid)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load board")
+	}
+
+	b.BaseBoard = board
+	b.spaceshipsAlive = bJSON.SpaceshipsAlive
+	b.killed = bJSON.Killed
+
+	return nil
+}
+
+type gameJSON struct {
+	GameID             string           `json:"game_id"`
+	Opponent           *Player          `json:"opponent"`
+	Rules              *Rules           `json:"rules"`
+	Status             GameStatus       `json:"status"`
+	SelfBoard          *SelfBoard       `json:"self_board"`
+	OpponentBoard      *OpponentBoard   `json:"opponent_board"`
+	PlayerTurn         WhichPlayer      `json:"player_turn"`
+	PlayerWon          WhichPlayer      `json:"player_won"`
+	SelfReady          bool             `json:"self_ready"`
+	OpponentReady      bool             `json:"opponent_ready"`
+	SelfCommitment     *BoardCommitment `json:"self_commitment"`
+	OpponentCommitment string           `json:"opponent_commitment"`
+	SelfSalvos         [][]*ShotResult  `json:"self_salvos"`
+	Verdict            Verdict          `json:"verdict"`
+	CheatReason        string           `json:"cheat_reason"`
+	Events             []*Event         `json:"events"`
+	NamedKills         bool             `json:"named_kills,omitempty"`
+}
+
+func (g *Game) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&gameJSON{
+		GameID:             g.GameID,
+		Opponent:           g.Opponent,
+		Rules:              g.Rules,
+		Status:             g.Status,
+		SelfBoard:          g.SelfBoard,
+		OpponentBoard:      g.OpponentBoard,
+		PlayerTurn:         g.PlayerTurn,
+		PlayerWon:          g.PlayerWon,
+		SelfReady:          g.SelfReady,
+		OpponentReady:      g.OpponentReady,
+		SelfCommitment:     g.SelfCommitment,
+		OpponentCommitment: g.OpponentCommitment,
+		SelfSalvos:         g.SelfSalvos,
+		Verdict:            g.Verdict,
+		CheatReason:        g.CheatReason,
+		Events:             g.Events,
+		NamedKills:         g.NamedKills,
+	})
+}
+
+func (g *Game) UnmarshalJSON(data []byte) error {
+	gJSON := &gameJSON{}
+	err := json.Unmarshal(data, gJSON)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to load game")
+	}
+
+	if gJSON.Rules == nil || gJSON.SelfBoard == nil || gJSON.OpponentBoard == nil {
+		return errors.New("Failed to load game: incomplete game")
+	}
+
+	// the boards should share the rules of the game
+	gJSON.SelfBoard.rules = gJSON.Rules
+	gJSON.OpponentBoard.rules = gJSON.Rules
+
+	*g = Game{
+		GameID:             gJSON.GameID,
+		Opponent:           gJSON.Opponent,
+		Rules:              gJSON.Rules,
+		Status:             gJSON.Status,
+		SelfBoard:          gJSON.SelfBoard,
+		OpponentBoard:      gJSON.OpponentBoard,
+		PlayerTurn:         gJSON.PlayerTurn,
+		PlayerWon:          gJSON.PlayerWon,
+		SelfReady:          gJSON.SelfReady,
+		OpponentReady:      gJSON.OpponentReady,
+		SelfCommitment:     gJSON.SelfCommitment,
+		OpponentCommitment: gJSON.OpponentCommitment,
+		SelfSalvos:         gJSON.SelfSalvos,
+		Verdict:            gJSON.Verdict,
+		CheatReason:        gJSON.CheatReason,
+		Events:             gJSON.Events,
+		NamedKills:         gJSON.NamedKills,
+		rng:                rand.New(NewRandomSource()),
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package ssgame
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameJSON(t *testing.T) {
+	assert := require.New(t)
+
+	game, err := CreateNewGame("match-1-1", &Player{
+		PlayerID:     "player-1",
+		FullName:     "Player 1",
+		ProtocolHost: "localhost",
+		ProtocolPort: 8001,
+	}, DefaultRules(), nil, NewSeededRandomSource(1), true)
+	assert.NoError(err)
+
+	// kill the first spaceship and hit the second one
+	killed := game.SelfBoard.Spaceships()[0]
+	game.SelfBoard.ReceiveSalvo(killed.coords)
+	hit := game.SelfBoard.Spaceships()[1]
+	game.SelfBoard.ReceiveSalvo(CoordsGroup{hit.coords[0]})
+
+	// a kill, a hit and a miss on our opponent
+	salvo := []*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill},
+		{Coords: mustCoordsFromString("1x0"), ShotStatus: ShotStatusHit},
+		{Coords: mustCoordsFromString("2x0"), ShotStatus: ShotStatusMiss},
+	}
+	for _, shotRes := range salvo {
+		game.OpponentBoard.ApplyShotStatus(shotRes.Coords, shotRes.ShotStatus)
+	}
+	game.RecordSelfSalvo(salvo)
+	assert.NoError(game.SetOpponentCommitment("abcdef"))
+
+	data, err := json.Marshal(game)
+	assert.NoError(err)
+
+	loaded := &Game{}
+	assert.NoError(json.Unmarshal(data, loaded))
+
+	assert.Equal(game.GameID, loaded.GameID)
+	assert.Equal(game.Opponent, loaded.Opponent)
+	assert.Equal(game.Status, loaded.Status)
+	assert.Equal(game.PlayerTurn, loaded.PlayerTurn)
+	assert.Equal(game.SelfCommitment.Hash, loaded.SelfCommitment.Hash)
+	assert.Equal(game.SelfCommitment.Salt, loaded.SelfCommitment.Salt)
+	assert.Equal("abcdef", loaded.OpponentCommitment)
+	assert.Equal(game.SelfSalvos, loaded.SelfSalvos)
+	assert.NotNil(loaded.Rand())
+
+	// the boards should share the rules of the game
+	assert.True(loaded.Rules == loaded.SelfBoard.Rules())
+	assert.True(loaded.Rules == loaded.OpponentBoard.Rules())
+	assert.Equal(SalvoRuleShipsAliveName, loaded.Rules.Salvo.Name())
+
+	assert.Equal(game.SelfBoard.ToPattern(), loaded.SelfBoard.ToPattern())
+	assert.Equal(game.OpponentBoard.ToPattern(), loaded.OpponentBoard.ToPattern())
+	assert.Equal(CoordsSunk, loaded.SelfBoard.CoordsState(killed.coords[0]))
+	assert.Equal(CoordsSunk, loaded.OpponentBoard.CoordsState(mustCoordsFromString("0x0")))
+	assert.Equal(game.SelfBoard.CountShipsAlive(), loaded.SelfBoard.CountShipsAlive())
+	assert.Equal(game.OpponentBoard.CountShipsAlive(), loaded.OpponentBoard.CountShipsAlive())
+
+	loadedKilled := loaded.SelfBoard.Spaceships()[0]
+	assert.True(loadedKilled.dead)
+	assert.Equal(killed.Name(), loadedKilled.Name())
+	assert.Equal(game.SelfBoard.FleetAlive(), loaded.SelfBoard.FleetAlive())
+	assert.Equal(killed.hits, loadedKilled.hits)
+
+	// the cells should point to the same spaceship as the board, so we can finish off the hit spaceship
+	loadedHit := loaded.SelfBoard.Spaceships()[1]
+	assert.False(loadedHit.dead)
+	assert.Equal(1, len(loadedHit.hits))
+
+	res := loaded.SelfBoard.ReceiveSalvo(loadedHit.coords[1:])
+	assert.Equal(ShotStatusKill, res[len(res)-1].ShotStatus)
+	assert.True(loadedHit.dead)
+	assert.False(hit.dead)
+
+	// the same game should always give the same JSON
+	again, err := json.Marshal(game)
+	assert.NoError(err)
+	assert.Equal(string(data), string(again))
+}
+
+func TestRulesJSON(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	rules.Salvo = &FixedSalvoRule{N: 3}
+	rules.NoTouch = true
+	rules.ChainOnHit = true
+
+	data, err := json.Marshal(rules)
+	assert.NoError(err)
+
+	loaded := &Rules{}
+	assert.NoError(json.Unmarshal(data, loaded))
+	assert.Equal(rules, loaded)
+
+	// invalid rules should not load
+	assert.Error(json.Unmarshal([]byte(`{"width": 0, "height": 16, "fleet": [], "salvo": "ships-alive"}`), &Rules{}))
+	assert.Error(json.Unmarshal([]byte(`{"width": 16, "height": 16, "fleet": [], "salvo": "bogus"}`), &Rules{}))
+}
+
+func TestSelfBoardJSONInvalid(t *testing.T) {
+	assert := require.New(t)
+
+	rules := `{"width": 2, "height": 2, "fleet": [{"name": "Dot", "pattern": ["*"], "count": 1}], "salvo": "single-shot"}`
+
+	// spaceship out of bounds
+	err := json.Unmarshal([]byte(`{"rules": `+rules+`, "grid": ["*.", ".."], "spaceships": [{"coords": [[2, 0]]}]}`), &SelfBoard{})
+	assert.Error(err)
+
+	// overlapping spaceships
+	err = json.Unmarshal([]byte(`{"rules": `+rules+`, "grid": ["*.", ".."], "spaceships": [{"coords": [[0, 0]]}, {"coords": [[0, 0]]}]}`), &SelfBoard{})
+	assert.Error(err)
+
+	// wrong dimensions
+	err = json.Unmarshal([]byte(`{"rules": `+rules+`, "grid": ["*.."], "spaceships": [{"coords": [[0, 0]]}]}`), &SelfBoard{})
+	assert.Error(err)
+
+	// valid
+	board := &SelfBoard{}
+	err = json.Unmarshal([]byte(`{"rules": `+rules+`, "grid": ["*.", ".."], "spaceships": [{"coords": [[0, 0]]}]}`), board)
+	assert.NoError(err)
+	assert.Equal(ShotStatusKill, board.ApplyShot(mustCoordsFromString("0x0")).ShotStatus)
+}
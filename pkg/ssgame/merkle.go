@@ -0,0 +1,164 @@
+package ssgame
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// prefixes to make sure a leaf can never be mistaken for a node and the other way around
+const merkleLeafPrefix = 0x00
+const merkleNodePrefix = 0x01
+
+// the proof that a cell of a board is part of the merkle root we committed to
+//  Salt is the salt of the cell (derived from the salt of the commitment), Proof are the hashes of the siblings from leaf to root
+type CellProof struct {
+	Salt  string
+	Proof []string
+}
+
+// merkle tree over every cell of a board, row by row, the salt of every cell is derived from the salt of the commitment
+//  so we can reveal the salt of a single cell without revealing anything about the other cells
+//  when a level has an odd number of nodes the last one is moved up to the next level as is
+type boardMerkleTree struct {
+	rules  *Rules
+	salt   string
+	levels [][][]byte
+}
+
+func newBoardMerkleTree(rules *Rules, pattern []string, salt string) *boardMerkleTree {
+	tree := &boardMerkleTree{
+		rules: rules,
+		salt:  salt,
+	}
+
+	leaves := make([][]byte, 0, rules.Width*rules.Height)
+	for y, row := range pattern {
+		for x, char := range []byte(row) {
+			coords := &Coords{x: int8(x), y: int8(y)}
+			leaves = append(leaves, merkleLeaf(cellSalt(salt, coords), coords, CoordsState(char)))
+		}
+	}
+
+	tree.levels = [][][]byte{leaves}
+	for level := leaves; len(level) > 1; {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+			} else {
+				next = append(next, merkleNode(level[i], level[i+1]))
+			}
+		}
+
+		tree.levels = append(tree.levels, next)
+		level = next
+	}
+
+	return tree
+}
+
+func (t *boardMerkleTree) root() string {
+	return hex.EncodeToString(t.levels[len(t.levels)-1][0])
+}
+
+func (t *boardMerkleTree) prove(coords *Coords) *CellProof {
+	proof := &CellProof{
+		Salt:  hex.EncodeToString(cellSalt(t.salt, coords)),
+		Proof: make([]string, 0, len(t.levels)),
+	}
+
+	i := int(coords.y)*t.rules.Width + int(coords.x)
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := i ^ 1
+		if sibling < len(level) {
+			proof.Proof = append(proof.Proof, hex.EncodeToString(level[sibling]))
+		}
+
+		i /= 2
+	}
+
+	return proof
+}
+
+// the merkle root over the cells of the pattern of a board
+func BoardMerkleRoot(rules *Rules, pattern []string, salt string) string {
+	return newBoardMerkleTree(rules, pattern, salt).root()
+}
+
+// check that a cell with a state is part of the board with the merkle root
+func VerifyCellProof(rules *Rules, root string, coords *Coords, state CoordsState, proof *CellProof) bool {
+	if proof == nil || !rules.InBounds(coords) {
+		return false
+	}
+
+	salt, err := hex.DecodeString(proof.Salt)
+	if err != nil {
+		return false
+	}
+
+	hash := merkleLeaf(salt, coords, state)
+
+	// walk up the tree the same way it was build, we need to know the size of every level to know when a node was moved up
+	i := int(coords.y)*rules.Width + int(coords.x)
+	size := rules.Width * rules.Height
+	proofHashes := proof.Proof
+	for size > 1 {
+		sibling := i ^ 1
+		if sibling < size {
+			if len(proofHashes) == 0 {
+				return false
+			}
+
+			siblingHash, err := hex.DecodeString(proofHashes[0])
+			if err != nil {
+				return false
+			}
+			proofHashes = proofHashes[1:]
+
+			if i%2 == 0 {
+				hash = merkleNode(hash, siblingHash)
+			} else {
+				hash = merkleNode(siblingHash, hash)
+			}
+		}
+
+		i /= 2
+		size = (size + 1) / 2
+	}
+
+	rootHash, err := hex.DecodeString(root)
+	if err != nil {
+		return false
+	}
+
+	return len(proofHashes) == 0 && bytes.Equal(hash, rootHash)
+}
+
+// the salt for a cell, derived from the salt of the commitment
+func cellSalt(salt string, coords *Coords) []byte {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(coords.String()))
+
+	return mac.Sum(nil)
+}
+
+func merkleLeaf(salt []byte, coords *Coords, state CoordsState) []byte {
+	h := sha256.New()
+	h.Write([]byte{merkleLeafPrefix})
+	h.Write(salt)
+	h.Write([]byte(coords.String()))
+	h.Write([]byte{byte(state)})
+
+	return h.Sum(nil)
+}
+
+func merkleNode(left []byte, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{merkleNodePrefix})
+	h.Write(left)
+	h.Write(right)
+
+	return h.Sum(nil)
+}
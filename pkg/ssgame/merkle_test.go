@@ -0,0 +1,235 @@
+package ssgame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardMerkleTree(t *testing.T) {
+	assert := require.New(t)
+
+	// an odd number of cells so some nodes are moved up a level
+	rules := &Rules{Width: 3, Height: 5}
+	pattern := []string{
+		"*..",
+		"*..",
+		"...",
+		"..*",
+		"..*",
+	}
+	salt := "c0ffee"
+
+	tree := newBoardMerkleTree(rules, pattern, salt)
+	root := tree.root()
+	assert.Equal(root, BoardMerkleRoot(rules, pattern, salt))
+	assert.NotEqual(root, BoardMerkleRoot(rules, pattern, "c0ffef"))
+
+	for y, row := range pattern {
+		for x, char := range []byte(row) {
+			coords := &Coords{x: int8(x), y: int8(y)}
+			proof := tree.prove(coords)
+
+			state := CoordsState(char)
+			otherState := CoordsShip
+			if state == CoordsShip {
+				otherState = CoordsBlank
+			}
+
+			assert.True(VerifyCellProof(rules, root, coords, state, proof), coords.String())
+			assert.False(VerifyCellProof(rules, root, coords, otherState, proof), coords.String())
+
+			// the proof is only valid for this cell
+			other := &Coords{x: int8((x + 1) % 3), y: int8(y)}
+			assert.False(VerifyCellProof(rules, root, other, state, proof), coords.String())
+			assert.False(VerifyCellProof(rules, root, other, otherState, proof), coords.String())
+		}
+	}
+
+	coords := &Coords{x: 0, y: 0}
+	proof := tree.prove(coords)
+
+	// tampered proof
+	proof.Proof[0] = proof.Proof[1]
+	assert.False(VerifyCellProof(rules, root, coords, CoordsShip, proof))
+
+	// proof too short
+	proof = tree.prove(coords)
+	proof.Proof = proof.Proof[1:]
+	assert.False(VerifyCellProof(rules, root, coords, CoordsShip, proof))
+
+	// out of bounds
+	assert.False(VerifyCellProof(rules, root, &Coords{x: 3, y: 0}, CoordsShip, proof))
+	assert.False(VerifyCellProof(rules, root, coords, CoordsShip, nil))
+}
+
+func TestGame_VerifySalvoProofs(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+
+	board, err := NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Winger", Offset: mustCoordsFromString("0x0")},
+		{Name: "Angle", Offset: mustCoordsFromString("4x0")},
+		{Name: "A-Class", Offset: mustCoordsFromString("8x0")},
+		{Name: "B-Class", Offset: mustCoordsFromString("0x6")},
+		{Name: "S-Class", Offset: mustCoordsFromString("Bx9"), Rotation: 90},
+	})
+	assert.NoError(err)
+	commitment, err := CommitToBoard(board)
+	assert.NoError(err)
+
+	game, err := InitNewGame("match-1", &Player{PlayerID: "player-2"}, rules, nil, NewSeededRandomSource(1), PlayerSelf)
+	assert.NoError(err)
+	assert.NoError(game.SetOpponentCommitment(commitment.Hash))
+
+	proofs := func(coords ...string) map[Coords]*CellProof {
+		res := make(map[Coords]*CellProof)
+		for _, coordsStr := range coords {
+			c := mustCoordsFromString(coordsStr)
+			res[*c] = commitment.Prove(rules, c)
+		}
+
+		return res
+	}
+
+	// honest results
+	assert.NoError(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+		{Coords: mustCoordsFromString("1x0"), ShotStatus: ShotStatusMiss},
+		{Coords: &Coords{x: 20, y: 0}, ShotStatus: ShotStatusMiss},
+	}, proofs("0x0", "1x0")))
+
+	// a miss on a spaceship
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusMiss},
+	}, proofs("0x0")))
+
+	// a hit on a blank cell
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("1x0"), ShotStatus: ShotStatusHit},
+	}, proofs("1x0")))
+
+	// no proof
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+	}, proofs()))
+
+	// a proof for a different cell
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+	}, map[Coords]*CellProof{*mustCoordsFromString("0x0"): commitment.Prove(rules, mustCoordsFromString("2x0"))}))
+
+	// a hit outside of the board
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: &Coords{x: 20, y: 0}, ShotStatus: ShotStatusHit},
+	}, proofs()))
+
+	// shooting the same spaceship cell twice in a salvo results in a miss
+	assert.NoError(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusMiss},
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusMiss},
+	}, proofs("0x0")))
+
+	// and so does shooting a spaceship cell we already hit
+	game.OpponentBoard.ApplyShotStatus(mustCoordsFromString("0x0"), ShotStatusHit)
+	assert.NoError(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusMiss},
+	}, proofs("0x0")))
+
+	// so a hit or kill on it is a lie, even with a valid proof
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+	}, proofs("0x0")))
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill},
+	}, proofs("0x0")))
+
+	// and the same goes for a cell of a spaceship we sunk
+	game.OpponentBoard.ApplyShotStatus(mustCoordsFromString("4x0"), ShotStatusKill)
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("4x0"), ShotStatus: ShotStatusHit},
+	}, proofs("4x0")))
+
+	// which we also know when our opponent didn't commit to a board
+	game.OpponentCommitment = ""
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusHit},
+	}, nil))
+	assert.NoError(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusMiss},
+		{Coords: mustCoordsFromString("1x0"), ShotStatus: ShotStatusHit},
+	}, nil))
+}
+
+func TestGame_VerifySalvoProofsKills(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	rules.Fleet = Fleet{
+		{Name: "Dot", Pattern: []string{"*"}, Count: 2},
+	}
+
+	game, err := InitNewGame("match-1", &Player{PlayerID: "player-2"}, rules, nil, NewSeededRandomSource(1), PlayerSelf)
+	assert.NoError(err)
+
+	// 2 spaceships alive so 2 kills are fine, a 3rd is a lie
+	assert.NoError(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill},
+		{Coords: mustCoordsFromString("2x0"), ShotStatus: ShotStatusKill},
+	}, nil))
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill},
+		{Coords: mustCoordsFromString("2x0"), ShotStatus: ShotStatusKill},
+		{Coords: mustCoordsFromString("4x0"), ShotStatus: ShotStatusKill},
+	}, nil))
+
+	// and once 1 is dead only 1 more kill is fine
+	game.OpponentBoard.ApplyShotStatus(mustCoordsFromString("0x0"), ShotStatusKill)
+	assert.NoError(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("2x0"), ShotStatus: ShotStatusKill},
+	}, nil))
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("2x0"), ShotStatus: ShotStatusKill},
+		{Coords: mustCoordsFromString("4x0"), ShotStatus: ShotStatusKill},
+	}, nil))
+}
+
+func TestGame_VerifySalvoProofsKillNames(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	rules.Fleet = Fleet{
+		{Name: "Dot", Pattern: []string{"*"}, Count: 2},
+		{Name: "Line", Pattern: []string{"**"}, Count: 1},
+	}
+
+	game, err := InitNewGame("match-1", &Player{PlayerID: "player-2"}, rules, nil, NewSeededRandomSource(1), PlayerSelf)
+	assert.NoError(err)
+
+	assert.NoError(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill, Spaceship: "Dot"},
+		{Coords: mustCoordsFromString("2x0"), ShotStatus: ShotStatusKill, Spaceship: "Dot"},
+	}, nil))
+
+	// a spaceship that's not part of the fleet
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill, Spaceship: "Titanic"},
+	}, nil))
+
+	// more kills of a spaceship than the fleet has
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill, Spaceship: "Line"},
+		{Coords: mustCoordsFromString("2x0"), ShotStatus: ShotStatusKill, Spaceship: "Line"},
+	}, nil))
+
+	// including the ones we killed before
+	game.OpponentBoard.ApplyShotResult(&ShotResult{Coords: mustCoordsFromString("0x0"), ShotStatus: ShotStatusKill, Spaceship: "Dot"})
+	game.OpponentBoard.ApplyShotResult(&ShotResult{Coords: mustCoordsFromString("2x0"), ShotStatus: ShotStatusKill, Spaceship: "Dot"})
+	assert.Error(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("4x0"), ShotStatus: ShotStatusKill, Spaceship: "Dot"},
+	}, nil))
+	assert.NoError(game.VerifySalvoProofs([]*ShotResult{
+		{Coords: mustCoordsFromString("4x0"), ShotStatus: ShotStatusKill, Spaceship: "Line"},
+	}, nil))
+}
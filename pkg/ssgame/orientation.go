@@ -0,0 +1,127 @@
+package ssgame
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// the orientation of a spaceship, it's rotated clockwise by Rotation degrees (0, 90, 180 or 270)
+//  with Mirror it's mirrored (flipped left to right) before it's rotated,
+//  together that's all 8 ways a spaceship can be put on the board
+type Orientation struct {
+	Rotation uint16
+	Mirror   bool
+}
+
+var rotations = []uint16{0, 90, 180, 270}
+
+// the 4 rotations, and with mirror the 4 rotations of the mirror image as well
+func Orientations(mirror bool) []Orientation {
+	orientations := make([]Orientation, 0, 8)
+	for _, rotation := range rotations {
+		orientations = append(orientations, Orientation{Rotation: rotation})
+	}
+
+	if mirror {
+		for _, rotation := range rotations {
+			orientations = append(orientations, Orientation{Rotation: rotation, Mirror: true})
+		}
+	}
+
+	return orientations
+}
+
+func (o Orientation) Validate() error {
+	for _, rotation := range rotations {
+		if o.Rotation == rotation {
+			return nil
+		}
+	}
+
+	return errors.Errorf("Unsupported rotate: %d", o.Rotation)
+}
+
+// the coords in the orientation, this is around 0x0 so the result can be negative
+func (o Orientation) transform(x int8, y int8) (int8, int8) {
+	if o.Mirror {
+		x = x * -1
+	}
+
+	// y goes down on the board, so this turns right into down which is clockwise
+	switch o.Rotation {
+	case 90:
+		return y * -1, x
+	case 180:
+		return x * -1, y * -1
+	case 270:
+		return y, x * -1
+	default:
+		return x, y
+	}
+}
+
+func (o Orientation) String() string {
+	if o.Mirror {
+		return fmt.Sprintf("%d mirrored", o.Rotation)
+	}
+
+	return fmt.Sprintf("%d", o.Rotation)
+}
+
+// the orientations of a pattern that result in a different shape, the first one for every shape
+//  so for a square that's only the first orientation and for a line it's only 0 and 90
+func UniqueOrientations(pattern []string, mirror bool) ([]Orientation, error) {
+	spaceship, err := SpaceshipFromPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	orientations := make([]Orientation, 0, 8)
+	for _, oriented := range spaceship.uniqueOrientations(mirror) {
+		orientations = append(orientations, oriented.orientation)
+	}
+
+	return orientations, nil
+}
+
+type orientedSpaceship struct {
+	orientation Orientation
+	spaceship   *Spaceship
+}
+
+func (s *Spaceship) uniqueOrientations(mirror bool) []*orientedSpaceship {
+	res := make([]*orientedSpaceship, 0, 8)
+	seen := make(map[string]bool, 8)
+
+	for _, orientation := range Orientations(mirror) {
+		oriented := s.CopyWithOrientation(orientation)
+
+		key := strings.Join(oriented.ToPattern(), "\n")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		res = append(res, &orientedSpaceship{
+			orientation: orientation,
+			spaceship:   oriented,
+		})
+	}
+
+	return res
+}
+
+// all the orientations of a spaceship, without the ones that result in the same shape
+//  that's the 4 rotations, and with mirror the 4 rotations of the mirror image as well
+func (s *Spaceship) Orientations(mirror bool) []*Spaceship {
+	oriented := s.uniqueOrientations(mirror)
+
+	res := make([]*Spaceship, len(oriented))
+	for i, o := range oriented {
+		res[i] = o.spaceship
+	}
+
+	return res
+}
@@ -0,0 +1,111 @@
+package ssgame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrientations(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal(4, len(Orientations(false)))
+	assert.Equal(8, len(Orientations(true)))
+
+	assert.NoError(Orientation{Rotation: 270, Mirror: true}.Validate())
+	assert.Error(Orientation{Rotation: 45}.Validate())
+}
+
+func TestSpaceship_Orient(t *testing.T) {
+	assert := require.New(t)
+
+	spaceship, err := SpaceshipFromPattern([]string{
+		"**",
+		"*",
+		"*",
+	})
+	assert.NoError(err)
+
+	assert.Equal([]string{
+		"***",
+		"..*",
+	}, spaceship.CopyWithOrientation(Orientation{Rotation: 90}).ToPattern())
+	assert.Equal([]string{
+		".*",
+		".*",
+		"**",
+	}, spaceship.CopyWithOrientation(Orientation{Rotation: 180}).ToPattern())
+	assert.Equal([]string{
+		"*",
+		"***",
+	}, spaceship.CopyWithOrientation(Orientation{Rotation: 270}).ToPattern())
+
+	// mirrored is flipped left to right first and then rotated
+	assert.Equal([]string{
+		"**",
+		".*",
+		".*",
+	}, spaceship.CopyWithOrientation(Orientation{Mirror: true}).ToPattern())
+	assert.Equal([]string{
+		"..*",
+		"***",
+	}, spaceship.CopyWithOrientation(Orientation{Rotation: 90, Mirror: true}).ToPattern())
+}
+
+func TestUniqueOrientations(t *testing.T) {
+	assert := require.New(t)
+
+	orientations, err := UniqueOrientations([]string{"**", "**"}, true)
+	assert.NoError(err)
+	assert.Equal([]Orientation{{Rotation: 0}}, orientations)
+
+	orientations, err = UniqueOrientations([]string{"***"}, true)
+	assert.NoError(err)
+	assert.Equal([]Orientation{{Rotation: 0}, {Rotation: 90}}, orientations)
+
+	// the L looks different in every orientation
+	orientations, err = UniqueOrientations([]string{"**", "*", "*"}, false)
+	assert.NoError(err)
+	assert.Equal(4, len(orientations))
+	orientations, err = UniqueOrientations([]string{"**", "*", "*"}, true)
+	assert.NoError(err)
+	assert.Equal(8, len(orientations))
+
+	// the S-Class is the same upside down, so every rotation of 180 or more is a duplicate
+	orientations, err = UniqueOrientations(SpaceshipPatternSClass, true)
+	assert.NoError(err)
+	assert.Equal([]Orientation{{Rotation: 0}, {Rotation: 90}, {Rotation: 0, Mirror: true}, {Rotation: 90, Mirror: true}}, orientations)
+
+	_, err = UniqueOrientations([]string{"..."}, false)
+	assert.Error(err)
+}
+
+func TestNewSelfBoardFromPlacementsMirror(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  4,
+		Height: 4,
+		Fleet: Fleet{
+			{Name: "L", Pattern: []string{"**", "*", "*"}, Count: 1},
+		},
+		Salvo: &ShipsAliveSalvoRule{},
+	}
+
+	placements := []*Placement{
+		{Name: "L", Offset: mustCoordsFromString("1x1"), Rotation: 90, Mirror: true},
+	}
+
+	_, err := NewSelfBoardFromPlacements(rules, placements)
+	assert.Error(err)
+
+	rules.Mirror = true
+	board, err := NewSelfBoardFromPlacements(rules, placements)
+	assert.NoError(err)
+	assert.Equal([]string{
+		"....",
+		"...*",
+		".***",
+		"....",
+	}, board.ToPattern())
+}
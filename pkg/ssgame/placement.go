@@ -0,0 +1,506 @@
+package ssgame
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// the placement of 1 spaceship from the fleet on a board, chosen by the player
+//  the spaceship is first mirrored (when the rules allow it) and rotated, and then moved to the offset
+type Placement struct {
+	Name     string
+	Offset   *Coords
+	Rotation uint16
+	Mirror   bool
+}
+
+// create a board for ourselves with the spaceships placed where the player wants them
+//  every spaceship of the fleet should be placed exactly once (or as many times as the fleet has of them)
+func NewSelfBoardFromPlacements(rules *Rules, placements []*Placement) (*SelfBoard, error) {
+	board, err := NewBlankSelfBoard(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep track of how many of each spaceship we still need to place
+	remaining := make(map[string]int, len(rules.Fleet))
+	patterns := make(map[string][]string, len(rules.Fleet))
+	for _, fleetSpaceship := range rules.Fleet {
+		remaining[fleetSpaceship.Name] = fleetSpaceship.Count
+		patterns[fleetSpaceship.Name] = fleetSpaceship.Pattern
+	}
+
+	for _, placement := range placements {
+		pattern, ok := patterns[placement.Name]
+		if !ok {
+			return nil, errors.Errorf("Failed to place spaceship, [%s] is not part of the fleet", placement.Name)
+		}
+		if remaining[placement.Name] == 0 {
+			return nil, errors.Errorf("Failed to place spaceship, too many [%s]", placement.Name)
+		}
+		if placement.Offset == nil {
+			return nil, errors.Errorf("Failed to place spaceship, [%s] has no offset", placement.Name)
+		}
+
+		spaceship, err := SpaceshipFromPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		spaceship.name = placement.Name
+
+		if placement.Mirror && !rules.Mirror {
+			return nil, errors.Errorf("Failed to place spaceship [%s], mirrored spaceships are not allowed", placement.Name)
+		}
+
+		err = spaceship.orient(Orientation{Rotation: placement.Rotation, Mirror: placement.Mirror})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to place spaceship [%s]", placement.Name)
+		}
+		spaceship.offset(placement.Offset.x, placement.Offset.y)
+
+		err = board.AddSpaceshipOnCoords(spaceship)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to place spaceship [%s]", placement.Name)
+		}
+
+		remaining[placement.Name]--
+	}
+
+	for _, fleetSpaceship := range rules.Fleet {
+		if remaining[fleetSpaceship.Name] > 0 {
+			return nil, errors.Errorf("Failed to place spaceships, [%s] not placed", fleetSpaceship.Name)
+		}
+	}
+
+	return board, nil
+}
+
+// create a board for ourselves from a pattern with the spaceships drawn on it
+//  the pattern is split up into the spaceships of the fleet,
+//  when there's more than 1 way to do that (spaceships that touch each other) we just use the first one we find
+//  when the rules don't allow spaceships to touch then only a split where none of the spaceships touch is valid
+func NewSelfBoardFromPattern(rules *Rules, pattern []string) (*SelfBoard, error) {
+	solver, err := newPatternSolverFromPattern(rules, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	spaceships := solver.solve(func(spaceships []*Spaceship) bool {
+		return true
+	})
+	if spaceships == nil {
+		return nil, errors.New("Failed to place spaceships, pattern does not match the fleet")
+	}
+
+	return newSelfBoardFromSpaceships(rules, spaceships)
+}
+
+func newSelfBoardFromSpaceships(rules *Rules, spaceships []*Spaceship) (*SelfBoard, error) {
+	board, err := NewBlankSelfBoard(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, spaceship := range spaceships {
+		err = board.AddSpaceshipOnCoords(spaceship.Copy())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return board, nil
+}
+
+// the spaceships of the fleet in every orientation, used to find which spaceships make up a pattern
+type patternSolverSpaceship struct {
+	remaining    int
+	orientations []*Spaceship
+}
+
+// finds the spaceships of the fleet that exactly cover the cells of a pattern
+//  covered are the cells of the pattern that are covered by the spaceships placed so far
+type patternSolver struct {
+	noTouch    bool
+	cells      Bitboard
+	covered    Bitboard
+	spaceships []*patternSolverSpaceship
+	placed     []*Spaceship
+}
+
+// create a solver for the spaceship cells of a pattern
+func newPatternSolverFromPattern(rules *Rules, pattern []string) (*patternSolver, error) {
+	// use a blank board to validate the pattern
+	patternBoard := newBaseBoard(rules)
+	err := FillBoardFromPattern(patternBoard, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if !patternBoard.hits.Empty() || !patternBoard.misses.Empty() || !patternBoard.sunk.Empty() {
+		return nil, errors.Errorf("Failed to place spaceships, pattern should only contain [%s] and [%s]", CoordsShipStr, CoordsBlankStr)
+	}
+
+	return newPatternSolver(rules, patternBoard.ships)
+}
+
+func newPatternSolver(rules *Rules, cells Bitboard) (*patternSolver, error) {
+	solver := &patternSolver{
+		noTouch:    rules.NoTouch,
+		cells:      cells,
+		spaceships: make([]*patternSolverSpaceship, len(rules.Fleet)),
+	}
+
+	fleetCells := 0
+	for i, fleetSpaceship := range rules.Fleet {
+		spaceship, err := SpaceshipFromPattern(fleetSpaceship.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		spaceship.name = fleetSpaceship.Name
+
+		fleetCells += len(spaceship.coords) * fleetSpaceship.Count
+
+		solver.spaceships[i] = &patternSolverSpaceship{
+			remaining:    fleetSpaceship.Count,
+			orientations: spaceship.Orientations(rules.Mirror),
+		}
+	}
+
+	if fleetCells != cells.Count() {
+		return nil, errors.Errorf("Failed to place spaceships, pattern has %d spaceship cells but the fleet has %d", cells.Count(), fleetCells)
+	}
+
+	return solver, nil
+}
+
+// returns the first split of the pattern into spaceships (with their offset) that is accepted,
+//  or nil when the pattern can't be made with the fleet or none of the splits are accepted
+func (s *patternSolver) solve(accept func(spaceships []*Spaceship) bool) []*Spaceship {
+	// the first cell (top to bottom, left to right) that isn't covered yet
+	//  should be the first cell of whatever spaceship is covering it
+	uncovered := s.cells.Difference(s.covered)
+	first, ok := uncovered.First()
+	if !ok {
+		if !accept(s.placed) {
+			return nil
+		}
+
+		return s.placed
+	}
+
+	for _, solverSpaceship := range s.spaceships {
+		if solverSpaceship.remaining == 0 {
+			continue
+		}
+
+		for _, orientation := range solverSpaceship.orientations {
+			anchor := firstCoords(orientation.coords)
+			spaceship := orientation.CopyWithOffset(first.x-anchor.x, first.y-anchor.y)
+
+			cells, ok := BitboardFromCoords(spaceship.coords)
+			if !ok || !s.fits(cells) {
+				continue
+			}
+
+			covered := s.covered
+			s.covered = s.covered.Union(cells)
+			solverSpaceship.remaining--
+			s.placed = append(s.placed, spaceship)
+
+			if res := s.solve(accept); res != nil {
+				return res
+			}
+
+			s.placed = s.placed[:len(s.placed)-1]
+			solverSpaceship.remaining++
+			s.covered = covered
+		}
+	}
+
+	return nil
+}
+
+// check if the cells of a spaceship are all cells of the pattern that aren't covered yet
+func (s *patternSolver) fits(cells Bitboard) bool {
+	outside := cells.Difference(s.cells.Difference(s.covered))
+	if !outside.Empty() {
+		return false
+	}
+
+	// when spaceships can't touch then every spaceship cell next to this spaceship should be part of it
+	if s.noTouch {
+		touching := cells.Neighbourhood().Intersect(s.cells).Difference(cells)
+		if !touching.Empty() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// the first coords (top to bottom, left to right) of a group of coords
+func firstCoords(cg CoordsGroup) *Coords {
+	first := cg[0]
+	for _, coords := range cg[1:] {
+		if coords.y < first.y || (coords.y == first.y && coords.x < first.x) {
+			first = coords
+		}
+	}
+
+	return first
+}
+
+// the weight of a position when the weigher gives it no weight (or a negative one, or not a number),
+//  the weight can't be 0 or less for the random keys so these positions are just very unlikely
+const minPlacementWeight = 1e-9
+
+// a position a spaceship can be placed on, the spaceship in an orientation (moved so it starts at 0x0) and the offset for it
+//  blocks are the cells no other spaceship can be placed on when the spaceship is here
+type spaceshipPosition struct {
+	oriented *Spaceship
+	x        int8
+	y        int8
+	cells    Bitboard
+	blocks   Bitboard
+}
+
+// the spaceship placed on the position
+func (p *spaceshipPosition) spaceship() *Spaceship {
+	return p.oriented.CopyWithOffset(p.x, p.y)
+}
+
+// every position within the bounds of the board a spaceship can be placed on, in every orientation the rules allow
+func spaceshipPositions(rules *Rules, spaceship *Spaceship) []*spaceshipPosition {
+	positions := make([]*spaceshipPosition, 0)
+	for _, oriented := range spaceship.Orientations(rules.Mirror) {
+		anchor := &Coords{}
+		for i, coords := range oriented.coords {
+			if i == 0 || coords.x < anchor.x {
+				anchor.x = coords.x
+			}
+			if i == 0 || coords.y < anchor.y {
+				anchor.y = coords.y
+			}
+		}
+		oriented = oriented.CopyWithOffset(-anchor.x, -anchor.y)
+
+		cells, _ := BitboardFromCoords(oriented.coords)
+		width, height := oriented.size()
+
+		for y := 0; y+height <= rules.Height; y++ {
+			for x := 0; x+width <= rules.Width; x++ {
+				position := &spaceshipPosition{
+					oriented: oriented,
+					x:        int8(x),
+					y:        int8(y),
+					cells:    cells.Offset(x, y),
+				}
+
+				position.blocks = position.cells
+				if rules.NoTouch {
+					position.blocks = position.cells.Neighbourhood()
+				}
+
+				positions = append(positions, position)
+			}
+		}
+	}
+
+	return positions
+}
+
+// the positions that don't cover any of the blocked cells
+func legalPositions(positions []*spaceshipPosition, blocked Bitboard) []*spaceshipPosition {
+	legal := make([]*spaceshipPosition, 0, len(positions))
+	for _, position := range positions {
+		if !position.cells.Intersects(blocked) {
+			legal = append(legal, position)
+		}
+	}
+
+	return legal
+}
+
+// pick a random position for every spaceship, so that none of them overlap (or touch when the rules don't allow that)
+//  without a weigher every spaceship gets a position picked uniformly from all of its positions
+//  and we start over when it overlaps the spaceships before it, so every layout of the spaceships is as likely,
+//  on a crowded board that can take forever to get a layout where nothing overlaps though,
+//  so we take turns with a search that places the spaceships in order and picks every position from its legal positions,
+//  uniformly or weighed by the weigher when there is one, and tries another position when that leaves no room for the spaceships after it
+//  the layouts of that search aren't all as likely, it favours the layouts with the fewest alternatives along the way,
+//  but it's the only way when there's a weigher and it finds a layout when there's hardly any
+//  both get to try a number of positions before they start over and get twice as many every time,
+//  when the search gets to try every position without finding a layout we know for sure there's no layout at all
+func placeRandom(rules *Rules, positions [][]*spaceshipPosition, weigher PlacementWeigher, rng *rand.Rand) ([]*spaceshipPosition, error) {
+	cells := make([]int, len(positions)+1)
+	for i := len(positions) - 1; i >= 0; i-- {
+		if len(positions[i]) == 0 {
+			return nil, errors.New("Failed to create a random board, there's a spaceship that doesn't fit on the board")
+		}
+
+		cells[i] = cells[i+1] + positions[i][0].cells.Count()
+	}
+	if cells[0] > rules.Width*rules.Height {
+		return nil, errors.New("Failed to create a random board, the spaceships have more cells than the board")
+	}
+
+	placer := &randomPlacer{
+		bounds:    BoundsBitboard(rules),
+		positions: positions,
+		weigher:   weigher,
+		cells:     cells,
+		placed:    make([]*spaceshipPosition, len(positions)),
+		rng:       rng,
+	}
+
+	for budget := 1000; ; budget *= 2 {
+		if weigher == nil {
+			placer.budget = budget
+			if placer.sample() {
+				return placer.placed, nil
+			}
+		}
+
+		placer.budget = budget
+		if placer.place(0, Bitboard{}, Bitboard{}) {
+			return placer.placed, nil
+		}
+
+		if placer.budget > 0 {
+			return nil, errors.New("Failed to create a random board, there's no way to fit the spaceships on the board")
+		}
+	}
+}
+
+// the state of placeRandom, cells are the number of cells of the spaceships from that index on
+//  and budget is the number of positions we can still try before starting over
+type randomPlacer struct {
+	bounds    Bitboard
+	positions [][]*spaceshipPosition
+	weigher   PlacementWeigher
+	cells     []int
+	placed    []*spaceshipPosition
+	budget    int
+	rng       *rand.Rand
+}
+
+// pick a position for every spaceship uniformly from all of its positions, until none of them overlap
+//  we start over as soon as a position overlaps a spaceship before it, false when we run out of budget first
+func (p *randomPlacer) sample() bool {
+	for p.budget > 0 {
+		if p.sampleOnce() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *randomPlacer) sampleOnce() bool {
+	var blocked Bitboard
+	for i, positions := range p.positions {
+		if p.budget == 0 {
+			return false
+		}
+		p.budget--
+
+		position := positions[p.rng.Intn(len(positions))]
+		if position.cells.Intersects(blocked) {
+			return false
+		}
+
+		p.placed[i] = position
+		blocked = blocked.Union(position.blocks)
+	}
+
+	return true
+}
+
+// place the spaceship with index i and the ones after it, blocked are the cells they can't be placed on
+//  and placed are the cells of the spaceships that are placed before it
+func (p *randomPlacer) place(i int, blocked Bitboard, placed Bitboard) bool {
+	if i == len(p.positions) {
+		return true
+	}
+
+	legal := legalPositions(p.positions[i], blocked)
+	for _, j := range p.order(legal, placed) {
+		if p.budget == 0 {
+			return false
+		}
+		p.budget--
+
+		next := blocked.Union(legal[j].blocks)
+		if p.deadEnd(i+1, next) {
+			continue
+		}
+
+		p.placed[i] = legal[j]
+		if p.place(i+1, next, placed.Union(legal[j].cells)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// the order to try the positions in (as indexes), the first is picked at random (weighed by the weigher),
+//  then the second from the ones that are left, and so on
+func (p *randomPlacer) order(positions []*spaceshipPosition, placed Bitboard) []int {
+	if p.weigher == nil {
+		return p.rng.Perm(len(positions))
+	}
+
+	// sorting by a random key that's smaller for a bigger weight does exactly that (exponential keys, Efraimidis-Spirakis)
+	keys := make([]float64, len(positions))
+	order := make([]int, len(positions))
+	for j, position := range positions {
+		weight := p.weigher(position.cells, placed)
+		if !(weight > minPlacementWeight) {
+			weight = minPlacementWeight
+		}
+
+		keys[j] = -math.Log(1-p.rng.Float64()) / weight
+		order[j] = j
+	}
+
+	sort.Slice(order, func(a, b int) bool {
+		return keys[order[a]] < keys[order[b]]
+	})
+
+	return order
+}
+
+// check if there's no way to place the spaceships from index i on, without trying all of them,
+//  because there's not enough cells left for them or because one of them has nowhere to go
+func (p *randomPlacer) deadEnd(i int, blocked Bitboard) bool {
+	if p.bounds.Difference(blocked).Count() < p.cells[i] {
+		return true
+	}
+
+	for j, positions := range p.positions[i:] {
+		// the same spaceships share their positions, no need to check those twice in a row
+		if j > 0 && &positions[0] == &p.positions[i+j-1][0] {
+			continue
+		}
+
+		if !anyLegalPosition(positions, blocked) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func anyLegalPosition(positions []*spaceshipPosition, blocked Bitboard) bool {
+	for _, position := range positions {
+		if !position.cells.Intersects(blocked) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,289 @@
+package ssgame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustCoordsFromString(coordsStr string) *Coords {
+	coords, err := CoordsFromString(coordsStr)
+	if err != nil {
+		panic(err)
+	}
+
+	return coords
+}
+
+func TestNewSelfBoardFromPlacements(t *testing.T) {
+	assert := require.New(t)
+
+	board, err := NewSelfBoardFromPlacements(DefaultRules(), []*Placement{
+		{Name: "Winger", Offset: mustCoordsFromString("0x0"), Rotation: 0},
+		{Name: "Angle", Offset: mustCoordsFromString("4x0"), Rotation: 0},
+		{Name: "A-Class", Offset: mustCoordsFromString("8x0"), Rotation: 0},
+		{Name: "B-Class", Offset: mustCoordsFromString("0x6"), Rotation: 0},
+		{Name: "S-Class", Offset: mustCoordsFromString("Bx9"), Rotation: 90},
+	})
+	assert.NoError(err)
+	assert.Equal(5, len(board.Spaceships()))
+
+	assert.Equal([]string{
+		"*.*.*....*......",
+		"*.*.*...*.*.....",
+		".*..*...***.....",
+		"*.*.***.*.*.....",
+		"*.*.............",
+		"................",
+		"**..............",
+		"*.*.............",
+		"**..............",
+		"*.*...........*.",
+		"**.........*.*.*",
+		"...........*.*.*",
+		"............*...",
+		"................",
+		"................",
+		"................",
+	}, board.ToPattern())
+}
+
+func TestNewSelfBoardFromPlacementsInvalid(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  8,
+		Height: 8,
+		Fleet: Fleet{
+			{Name: "Line", Pattern: []string{"***"}, Count: 2},
+		},
+	}
+
+	// not enough spaceships
+	_, err := NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Line", Offset: mustCoordsFromString("0x0")},
+	})
+	assert.Error(err)
+
+	// too many spaceships
+	_, err = NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Line", Offset: mustCoordsFromString("0x0")},
+		{Name: "Line", Offset: mustCoordsFromString("0x1")},
+		{Name: "Line", Offset: mustCoordsFromString("0x2")},
+	})
+	assert.Error(err)
+
+	// unknown spaceship
+	_, err = NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Line", Offset: mustCoordsFromString("0x0")},
+		{Name: "Dot", Offset: mustCoordsFromString("0x1")},
+	})
+	assert.Error(err)
+
+	// overlapping spaceships
+	_, err = NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Line", Offset: mustCoordsFromString("0x0")},
+		{Name: "Line", Offset: mustCoordsFromString("1x0"), Rotation: 90},
+	})
+	assert.Error(err)
+
+	// out of bounds
+	_, err = NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Line", Offset: mustCoordsFromString("0x0")},
+		{Name: "Line", Offset: mustCoordsFromString("6x0")},
+	})
+	assert.Error(err)
+
+	// invalid rotation
+	_, err = NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Line", Offset: mustCoordsFromString("0x0")},
+		{Name: "Line", Offset: mustCoordsFromString("0x2"), Rotation: 45},
+	})
+	assert.Error(err)
+
+	// valid
+	board, err := NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Line", Offset: mustCoordsFromString("0x0")},
+		{Name: "Line", Offset: mustCoordsFromString("7x5"), Rotation: 90},
+	})
+	assert.NoError(err)
+	assert.Equal([]string{
+		"***.....",
+		"........",
+		"........",
+		"........",
+		"........",
+		".......*",
+		".......*",
+		".......*",
+	}, board.ToPattern())
+}
+
+func TestNewSelfBoardFromPattern(t *testing.T) {
+	assert := require.New(t)
+
+	pattern := []string{
+		"*.*.*....*......",
+		"*.*.*...*.*.....",
+		".*..*...***.....",
+		"*.*.***.*.*.....",
+		"*.*.............",
+		"................",
+		"**..............",
+		"*.*.............",
+		"**..............",
+		"*.*...........*.",
+		"**.........*.*.*",
+		"...........*.*.*",
+		"............*...",
+		"................",
+		"................",
+		"................",
+	}
+
+	board, err := NewSelfBoardFromPattern(DefaultRules(), pattern)
+	assert.NoError(err)
+	assert.Equal(5, len(board.Spaceships()))
+	assert.Equal(pattern, board.ToPattern())
+
+	// every spaceship should be killable on it's own
+	res := board.ReceiveSalvo(CoordsGroup{
+		mustCoordsFromString("4x0"),
+		mustCoordsFromString("4x1"),
+		mustCoordsFromString("4x2"),
+		mustCoordsFromString("4x3"),
+		mustCoordsFromString("5x3"),
+		mustCoordsFromString("6x3"),
+	})
+	assert.Equal(ShotStatusKill, res[5].ShotStatus)
+	assert.Equal(4, board.CountShipsAlive())
+}
+
+func TestNewSelfBoardFromPatternTouching(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  4,
+		Height: 4,
+		Fleet: Fleet{
+			{Name: "Angle", Pattern: []string{"*", "**"}, Count: 1},
+			{Name: "Line", Pattern: []string{"***"}, Count: 1},
+		},
+	}
+
+	board, err := NewSelfBoardFromPattern(rules, []string{
+		"*...",
+		"**..",
+		"***.",
+		"....",
+	})
+	assert.NoError(err)
+	assert.Equal(2, len(board.Spaceships()))
+	assert.Equal(2, board.CountShipsAlive())
+}
+
+func TestNewSelfBoardFromPatternInvalid(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  4,
+		Height: 4,
+		Fleet: Fleet{
+			{Name: "Line", Pattern: []string{"***"}, Count: 2},
+		},
+	}
+
+	// wrong dimensions
+	_, err := NewSelfBoardFromPattern(rules, []string{
+		"***",
+		"***",
+		"...",
+	})
+	assert.Error(err)
+
+	// marks on the pattern
+	_, err = NewSelfBoardFromPattern(rules, []string{
+		"***.",
+		"***X",
+		"....",
+		"....",
+	})
+	assert.Error(err)
+
+	// too few cells
+	_, err = NewSelfBoardFromPattern(rules, []string{
+		"***.",
+		"**..",
+		"....",
+		"....",
+	})
+	assert.Error(err)
+
+	// right amount of cells, wrong shapes
+	_, err = NewSelfBoardFromPattern(rules, []string{
+		"****",
+		"**..",
+		"....",
+		"....",
+	})
+	assert.Error(err)
+
+	// valid
+	_, err = NewSelfBoardFromPattern(rules, []string{
+		"***.",
+		"...*",
+		"...*",
+		"...*",
+	})
+	assert.NoError(err)
+}
+
+func TestNewSelfBoardFromPatternNoTouch(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  4,
+		Height: 4,
+		Fleet: Fleet{
+			{Name: "Angle", Pattern: []string{"*", "**"}, Count: 1},
+			{Name: "Line", Pattern: []string{"***"}, Count: 1},
+		},
+		NoTouch: true,
+	}
+
+	_, err := NewSelfBoardFromPattern(rules, []string{
+		"*...",
+		"**..",
+		"***.",
+		"....",
+	})
+	assert.Error(err)
+
+	_, err = NewSelfBoardFromPattern(rules, []string{
+		"*...",
+		"***.",
+		"..*.",
+		"..*.",
+	})
+	assert.Error(err)
+
+	_, err = NewSelfBoardFromPattern(rules, []string{
+		"*...",
+		"**..",
+		"....",
+		".***",
+	})
+	assert.NoError(err)
+
+	_, err = NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Angle", Offset: mustCoordsFromString("0x0")},
+		{Name: "Line", Offset: mustCoordsFromString("1x2")},
+	})
+	assert.Error(err)
+
+	_, err = NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Angle", Offset: mustCoordsFromString("0x0")},
+		{Name: "Line", Offset: mustCoordsFromString("1x3")},
+	})
+	assert.NoError(err)
+}
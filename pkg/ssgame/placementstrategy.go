@@ -0,0 +1,173 @@
+package ssgame
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	PlacementStrategyRandomName      = "random"
+	PlacementStrategyEdgeName        = "edge"
+	PlacementStrategyClusterName     = "cluster"
+	PlacementStrategySpreadName      = "spread"
+	PlacementStrategyAntiDensityName = "anti-density"
+)
+
+// how much more (or less) likely a position is for every cell of it that the strategy likes (or dislikes)
+const placementCellWeight = 4.0
+
+// decides where the spaceships of a random board are more likely to end up
+type PlacementStrategy interface {
+	Name() string
+	// the weigher for the positions of spaceships on a board with the rules, nil when every position is as likely
+	Weigher(rules *Rules) PlacementWeigher
+}
+
+// how likely it is that a spaceship is placed on the cells compared to the other positions for it,
+//  placed are the cells of the spaceships that are already on the board, a weight of 0 (or less) makes the position very unlikely
+type PlacementWeigher func(cells Bitboard, placed Bitboard) float64
+
+func PlacementStrategyFromName(name string) (PlacementStrategy, error) {
+	switch name {
+	case PlacementStrategyRandomName:
+		return &RandomPlacementStrategy{}, nil
+	case PlacementStrategyEdgeName:
+		return &EdgePlacementStrategy{}, nil
+	case PlacementStrategyClusterName:
+		return &ClusterPlacementStrategy{}, nil
+	case PlacementStrategySpreadName:
+		return &SpreadPlacementStrategy{}, nil
+	case PlacementStrategyAntiDensityName:
+		return &AntiDensityPlacementStrategy{}, nil
+	default:
+		return nil, errors.Errorf("Unknown placement strategy [%s]", name)
+	}
+}
+
+// every position is as likely
+type RandomPlacementStrategy struct{}
+
+func (s *RandomPlacementStrategy) Name() string {
+	return PlacementStrategyRandomName
+}
+
+func (s *RandomPlacementStrategy) Weigher(rules *Rules) PlacementWeigher {
+	return nil
+}
+
+// spaceships hug the edges of the board
+type EdgePlacementStrategy struct{}
+
+func (s *EdgePlacementStrategy) Name() string {
+	return PlacementStrategyEdgeName
+}
+
+func (s *EdgePlacementStrategy) Weigher(rules *Rules) PlacementWeigher {
+	var edge Bitboard
+	for y := 0; y < rules.Height; y++ {
+		for x := 0; x < rules.Width; x++ {
+			if x == 0 || y == 0 || x == rules.Width-1 || y == rules.Height-1 {
+				edge.Set(&Coords{x: int8(x), y: int8(y)})
+			}
+		}
+	}
+
+	return func(cells Bitboard, placed Bitboard) float64 {
+		return math.Pow(placementCellWeight, float64(cells.Intersect(edge).Count()))
+	}
+}
+
+// spaceships end up close to each other, within 2 cells of the spaceships placed before them
+type ClusterPlacementStrategy struct{}
+
+func (s *ClusterPlacementStrategy) Name() string {
+	return PlacementStrategyClusterName
+}
+
+func (s *ClusterPlacementStrategy) Weigher(rules *Rules) PlacementWeigher {
+	return nearWeigher(2, placementCellWeight)
+}
+
+// spaceships stay away from each other, cells within 3 cells of the spaceships placed before them are avoided
+type SpreadPlacementStrategy struct{}
+
+func (s *SpreadPlacementStrategy) Name() string {
+	return PlacementStrategySpreadName
+}
+
+func (s *SpreadPlacementStrategy) Weigher(rules *Rules) PlacementWeigher {
+	return nearWeigher(3, 1/placementCellWeight)
+}
+
+// a weigher that multiplies the weight for every cell that's within distance of the spaceships placed before,
+//  all positions for a spaceship are weighed with the same placed cells so we only figure out which cells are near once
+func nearWeigher(distance int, weight float64) PlacementWeigher {
+	var lastPlaced, near Bitboard
+	return func(cells Bitboard, placed Bitboard) float64 {
+		if placed != lastPlaced {
+			lastPlaced, near = placed, placed
+			for i := 0; i < distance; i++ {
+				near = near.Neighbourhood()
+			}
+		}
+
+		return math.Pow(weight, float64(cells.Intersect(near).Count()))
+	}
+}
+
+// spaceships avoid the cells that an AI that shoots at the cells most spaceships can be on would shoot at first,
+//  those are the cells that are covered by the most positions of the fleet on a blank board
+type AntiDensityPlacementStrategy struct{}
+
+func (s *AntiDensityPlacementStrategy) Name() string {
+	return PlacementStrategyAntiDensityName
+}
+
+func (s *AntiDensityPlacementStrategy) Weigher(rules *Rules) PlacementWeigher {
+	density := PlacementDensity(rules)
+
+	return func(cells Bitboard, placed Bitboard) float64 {
+		weight := 1.0
+		for _, coords := range cells.Coords() {
+			// a cell with the highest density makes a position placementCellWeight times less likely
+			weight *= 1 - (1-1/placementCellWeight)*density[coords.y][coords.x]
+		}
+
+		return weight
+	}
+}
+
+// the number of positions of the fleet on a blank board that cover every cell, by row and then column
+//  relative to the cell that is covered most, so the cell that is covered most is 1
+func PlacementDensity(rules *Rules) [][]float64 {
+	density := make([][]float64, rules.Height)
+	for y := range density {
+		density[y] = make([]float64, rules.Width)
+	}
+
+	max := 0.0
+	for _, fleetSpaceship := range rules.Fleet {
+		spaceship, err := SpaceshipFromPattern(fleetSpaceship.Pattern)
+		if err != nil {
+			continue
+		}
+
+		for _, position := range spaceshipPositions(rules, spaceship) {
+			for _, coords := range position.cells.Coords() {
+				density[coords.y][coords.x] += float64(fleetSpaceship.Count)
+				max = math.Max(max, density[coords.y][coords.x])
+			}
+		}
+	}
+
+	if max > 0 {
+		for _, row := range density {
+			for x := range row {
+				row[x] /= max
+			}
+		}
+	}
+
+	return density
+}
@@ -0,0 +1,172 @@
+package ssgame
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementStrategyFromName(t *testing.T) {
+	assert := require.New(t)
+
+	for _, name := range []string{
+		PlacementStrategyRandomName,
+		PlacementStrategyEdgeName,
+		PlacementStrategyClusterName,
+		PlacementStrategySpreadName,
+		PlacementStrategyAntiDensityName,
+	} {
+		strategy, err := PlacementStrategyFromName(name)
+		assert.NoError(err)
+		assert.Equal(name, strategy.Name())
+	}
+
+	_, err := PlacementStrategyFromName("bogus")
+	assert.Error(err)
+}
+
+// the average of some measure of the boards a strategy creates
+func averageBoards(assert *require.Assertions, rules *Rules, strategy PlacementStrategy, measure func(board *SelfBoard) float64) float64 {
+	rng := testRand()
+
+	total := 0.0
+	for i := 0; i < 50; i++ {
+		board, err := NewRandomSelfBoard(rules, rules.Fleet.Patterns(), strategy, rng)
+		assert.NoError(err)
+		assert.Equal(rules.Fleet.Size(), len(board.Spaceships()))
+
+		total += measure(board)
+	}
+
+	return total / 50
+}
+
+func TestPlacementStrategyEdge(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	edgeCells := func(board *SelfBoard) float64 {
+		edge := 0
+		for _, coords := range board.spaceshipCells.Coords() {
+			if coords.x == 0 || coords.y == 0 || int(coords.x) == rules.Width-1 || int(coords.y) == rules.Height-1 {
+				edge++
+			}
+		}
+
+		return float64(edge)
+	}
+
+	random := averageBoards(assert, rules, &RandomPlacementStrategy{}, edgeCells)
+	edge := averageBoards(assert, rules, &EdgePlacementStrategy{}, edgeCells)
+	assert.True(edge > random*2, "edge %f random %f", edge, random)
+}
+
+func TestPlacementStrategyClusterAndSpread(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	rules.NoTouch = true
+
+	// the cells of other spaceships within 3 cells of every spaceship
+	nearCells := func(board *SelfBoard) float64 {
+		near := 0
+		for _, spaceship := range board.Spaceships() {
+			around := spaceship.cells.Neighbourhood().Neighbourhood().Neighbourhood()
+			near += around.Intersect(board.spaceshipCells.Difference(spaceship.cells)).Count()
+		}
+
+		return float64(near)
+	}
+
+	random := averageBoards(assert, rules, &RandomPlacementStrategy{}, nearCells)
+	cluster := averageBoards(assert, rules, &ClusterPlacementStrategy{}, nearCells)
+	spread := averageBoards(assert, rules, &SpreadPlacementStrategy{}, nearCells)
+	assert.True(cluster > random*2, "cluster %f random %f", cluster, random)
+	assert.True(spread < random/2, "spread %f random %f", spread, random)
+}
+
+func TestPlacementStrategyAntiDensity(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	density := PlacementDensity(rules)
+
+	// the cells on the edges are covered least and the ones in the middle most
+	assert.True(density[0][0] < density[rules.Height/2][rules.Width/2])
+	assert.Equal(1.0, density[rules.Height/2][rules.Width/2])
+
+	totalDensity := func(board *SelfBoard) float64 {
+		total := 0.0
+		for _, coords := range board.spaceshipCells.Coords() {
+			total += density[coords.y][coords.x]
+		}
+
+		return total
+	}
+
+	random := averageBoards(assert, rules, &RandomPlacementStrategy{}, totalDensity)
+	antiDensity := averageBoards(assert, rules, &AntiDensityPlacementStrategy{}, totalDensity)
+	assert.True(antiDensity < random*0.75, "anti-density %f random %f", antiDensity, random)
+}
+
+// a strategy that gives no weight to any position, except for the ones on the first row
+type zeroPlacementStrategy struct {
+	weight float64
+}
+
+func (s *zeroPlacementStrategy) Name() string {
+	return "zero"
+}
+
+func (s *zeroPlacementStrategy) Weigher(rules *Rules) PlacementWeigher {
+	firstRow := firstRowCells(rules)
+
+	return func(cells Bitboard, placed Bitboard) float64 {
+		if cells.Intersects(firstRow) {
+			return 1
+		}
+
+		return s.weight
+	}
+}
+
+func firstRowCells(rules *Rules) Bitboard {
+	firstRow := Bitboard{}
+	for x := 0; x < rules.Width; x++ {
+		firstRow.Set(NewCoords(x, 0))
+	}
+
+	return firstRow
+}
+
+func TestPlacementStrategyZeroWeight(t *testing.T) {
+	assert := require.New(t)
+
+	rules := DefaultRules()
+	rules.Fleet = Fleet{{Name: "Line", Pattern: []string{"***"}, Count: 2}}
+
+	// positions without weight (or a negative weight, or not a number) are still possible but a lot less likely
+	for _, weight := range []float64{0, -1, math.NaN()} {
+		firstRow := 0
+		for i := 0; i < 20; i++ {
+			board, err := NewRandomSelfBoard(rules, rules.Fleet.Patterns(), &zeroPlacementStrategy{weight}, testRand())
+			assert.NoError(err)
+			assert.Equal(2, len(board.Spaceships()))
+
+			for _, spaceship := range board.Spaceships() {
+				if spaceship.cells.Intersects(firstRowCells(rules)) {
+					firstRow++
+				}
+			}
+		}
+
+		assert.Equal(40, firstRow, "weight %f", weight)
+	}
+
+	// a crowded board still works when most positions have no weight
+	rules.Fleet = Fleet{{Name: "Line", Pattern: []string{"***"}, Count: 20}}
+	board, err := NewRandomSelfBoard(rules, rules.Fleet.Patterns(), &zeroPlacementStrategy{0}, testRand())
+	assert.NoError(err)
+	assert.Equal(20, len(board.Spaceships()))
+}
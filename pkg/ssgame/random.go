@@ -1,6 +1,8 @@
 package ssgame
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
 	"fmt"
 	"math/rand"
 )
@@ -16,8 +18,25 @@ func RandomGameID() string {
 	return fmt.Sprintf("match-%d-%d", UUID, gameID)
 }
 
-func RandomFirstPlayer() WhichPlayer {
-	if rand.Intn(2) == 0 {
+// a new random source seeded from crypto/rand, so that every game gets different boards
+//  the source itself is still math/rand, it's only the seed that comes from crypto/rand
+func NewRandomSource() rand.Source {
+	var seed [8]byte
+	_, err := cryptorand.Read(seed[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return rand.NewSource(int64(binary.LittleEndian.Uint64(seed[:])))
+}
+
+// a new random source with an explicit seed, so the "randomness" is the same every time (eg for tests)
+func NewSeededRandomSource(seed int64) rand.Source {
+	return rand.NewSource(seed)
+}
+
+func RandomFirstPlayer(rng *rand.Rand) WhichPlayer {
+	if rng.Intn(2) == 0 {
 		return PlayerSelf
 	} else {
 		return PlayerOpponent
@@ -0,0 +1,89 @@
+package ssgame
+
+import (
+	"github.com/pkg/errors"
+)
+
+// the dimensions of the board when nothing else is specified
+const DefaultWidth = 16
+const DefaultHeight = 16
+
+// the limits for the dimensions of the board
+//  coords are stored as int8 and parsed from max 2 hex digits, so we could go bigger, but a 32x32 game is long enough already
+const MinWidth = 1
+const MinHeight = 1
+const MaxWidth = 32
+const MaxHeight = 32
+
+// the type to hold the rules for a game, every board of the game is created according to these
+//  with ManualPlacement the players place their own spaceships before the game starts, otherwise they're placed randomly
+//  with NoTouch spaceships are not allowed to be placed next to each other (also not diagonally)
+//  Salvo determines how many shots each player gets per salvo
+//  with ChainOnHit a player keeps the turn for as long as his salvos hit something
+//  with Mirror spaceships can also be placed as their mirror image, otherwise they can only be rotated
+type Rules struct {
+	Width           int
+	Height          int
+	Fleet           Fleet
+	ManualPlacement bool
+	NoTouch         bool
+	Salvo           SalvoRule
+	ChainOnHit      bool
+	Mirror          bool
+}
+
+// the rules for a standard game
+func DefaultRules() *Rules {
+	return &Rules{
+		Width:  DefaultWidth,
+		Height: DefaultHeight,
+		Fleet:  BaseGameFleet(),
+		Salvo:  &ShipsAliveSalvoRule{},
+	}
+}
+
+// sanity check the rules, should be used for any rules that we didn't create ourselves
+func (r *Rules) Validate() error {
+	if r.Width < MinWidth || r.Width > MaxWidth {
+		return errors.Errorf("Invalid rules: width should be between %d and %d", MinWidth, MaxWidth)
+	}
+	if r.Height < MinHeight || r.Height > MaxHeight {
+		return errors.Errorf("Invalid rules: height should be between %d and %d", MinHeight, MaxHeight)
+	}
+
+	err := r.Fleet.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "Invalid rules")
+	}
+
+	if r.Salvo == nil {
+		return errors.New("Invalid rules: no salvo rule")
+	}
+
+	// make sure every spaceship can fit on the board, and that there's room for all of them
+	//  it's still possible that there's no way to place all the spaceships, but that we'll find out when trying to
+	for _, fleetSpaceship := range r.Fleet {
+		spaceship, _ := SpaceshipFromPattern(fleetSpaceship.Pattern)
+
+		width, height := spaceship.size()
+		if !(width <= r.Width && height <= r.Height) && !(height <= r.Width && width <= r.Height) {
+			return errors.Errorf("Invalid rules: spaceship [%s] does not fit on the board", fleetSpaceship.Name)
+		}
+	}
+
+	if r.Fleet.Cells() > r.Width*r.Height {
+		return errors.New("Invalid rules: fleet does not fit on the board")
+	}
+
+	return nil
+}
+
+// the orientations spaceships can be placed in
+func (r *Rules) Orientations() []Orientation {
+	return Orientations(r.Mirror)
+}
+
+// check if the coords are within the bounds of the board
+func (r *Rules) InBounds(coords *Coords) bool {
+	return coords.x >= 0 && int(coords.x) < r.Width && coords.y >= 0 && int(coords.y) < r.Height
+}
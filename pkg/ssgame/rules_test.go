@@ -0,0 +1,102 @@
+package ssgame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_Validate(t *testing.T) {
+	assert := require.New(t)
+
+	assert.NoError(DefaultRules().Validate())
+	assert.NoError((&Rules{Width: 10, Height: 10, Fleet: BaseGameFleet(), Salvo: &ShipsAliveSalvoRule{}}).Validate())
+	assert.NoError((&Rules{Width: 32, Height: 32, Fleet: BaseGameFleet(), Salvo: &ShipsAliveSalvoRule{}}).Validate())
+
+	assert.Error((&Rules{Width: 0, Height: 10, Fleet: BaseGameFleet(), Salvo: &ShipsAliveSalvoRule{}}).Validate())
+	assert.Error((&Rules{Width: 10, Height: 0, Fleet: BaseGameFleet(), Salvo: &ShipsAliveSalvoRule{}}).Validate())
+	assert.Error((&Rules{Width: 33, Height: 10, Fleet: BaseGameFleet(), Salvo: &ShipsAliveSalvoRule{}}).Validate())
+	assert.Error((&Rules{Width: 10, Height: 33, Fleet: BaseGameFleet(), Salvo: &ShipsAliveSalvoRule{}}).Validate())
+}
+
+func TestRules_InBounds(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{Width: 10, Height: 12}
+
+	assert.True(rules.InBounds(&Coords{0, 0}))
+	assert.True(rules.InBounds(&Coords{9, 11}))
+	assert.False(rules.InBounds(&Coords{10, 11}))
+	assert.False(rules.InBounds(&Coords{9, 12}))
+	assert.False(rules.InBounds(&Coords{-1, 0}))
+}
+
+func TestBoard_SmallBoard(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{Width: 10, Height: 10}
+
+	board, err := NewBlankSelfBoard(rules)
+	assert.NoError(err)
+	assert.Equal([]string{
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+	}, board.ToPattern())
+
+	spaceship, err := SpaceshipFromPattern([]string{
+		"***",
+	})
+	assert.NoError(err)
+
+	assert.NoError(board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(7, 9)))
+	assert.Error(board.AddSpaceshipOnCoords(spaceship.CopyWithOffset(8, 0)))
+
+	// shots outside of the board are always a miss and don't touch the board
+	res := board.ApplyShot(&Coords{12, 0})
+	assert.Equal(ShotStatusMiss, res.ShotStatus)
+	assert.Equal(0, board.CountMisses())
+
+	res = board.ApplyShot(&Coords{9, 9})
+	assert.Equal(ShotStatusHit, res.ShotStatus)
+}
+
+func TestBoard_BigBoard(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{Width: 32, Height: 32}
+
+	board, err := NewRandomSelfBoard(rules, SpaceshipsSetForBaseGame, nil, testRand())
+	assert.NoError(err)
+	assert.Equal(32, len(board.ToPattern()))
+	assert.Equal(32, len(board.ToPattern()[31]))
+
+	coords, err := CoordsFromString("1Fx1f")
+	assert.NoError(err)
+	assert.Equal("1Fx1F", coords.String())
+	assert.True(rules.InBounds(coords))
+	assert.False(DefaultRules().InBounds(coords))
+
+	_, err = CoordsFromString("100x0")
+	assert.Error(err)
+}
+
+func TestBoard_FillBoardFromPatternRules(t *testing.T) {
+	assert := require.New(t)
+
+	board := newBaseBoard(&Rules{Width: 3, Height: 2})
+
+	assert.NoError(FillBoardFromPattern(board, []string{
+		"X-*",
+		"...",
+	}))
+
+	assert.Error(FillBoardFromPattern(board, BlankBoardPattern(DefaultRules())))
+}
@@ -0,0 +1,91 @@
+package ssgame
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	SalvoRuleSingleShotName = "single-shot"
+	SalvoRuleShipsAliveName = "ships-alive"
+	SalvoRuleCellsAliveName = "cells-alive"
+	SalvoRuleFixedName      = "fixed"
+)
+
+// what a SalvoRule needs to know about a player's fleet to determine his salvo size,
+//  implemented by both SelfBoard and OpponentBoard so the rule can be applied to both players
+type FleetStatus interface {
+	CountShipsAlive() int
+	CountCellsAlive() int
+}
+
+// determines how many shots a player gets in a salvo
+type SalvoRule interface {
+	Name() string
+	Shots(fleet FleetStatus) int
+}
+
+// classic rules, 1 shot per salvo
+type SingleShotSalvoRule struct{}
+
+func (r *SingleShotSalvoRule) Name() string {
+	return SalvoRuleSingleShotName
+}
+
+func (r *SingleShotSalvoRule) Shots(fleet FleetStatus) int {
+	return 1
+}
+
+// 1 shot for every spaceship still alive
+type ShipsAliveSalvoRule struct{}
+
+func (r *ShipsAliveSalvoRule) Name() string {
+	return SalvoRuleShipsAliveName
+}
+
+func (r *ShipsAliveSalvoRule) Shots(fleet FleetStatus) int {
+	return fleet.CountShipsAlive()
+}
+
+// 1 shot for every spaceship cell that hasn't been hit
+type CellsAliveSalvoRule struct{}
+
+func (r *CellsAliveSalvoRule) Name() string {
+	return SalvoRuleCellsAliveName
+}
+
+func (r *CellsAliveSalvoRule) Shots(fleet FleetStatus) int {
+	return fleet.CountCellsAlive()
+}
+
+// the same number of shots every salvo
+type FixedSalvoRule struct {
+	N int
+}
+
+func (r *FixedSalvoRule) Name() string {
+	return SalvoRuleFixedName
+}
+
+func (r *FixedSalvoRule) Shots(fleet FleetStatus) int {
+	return r.N
+}
+
+// the SalvoRule for a name, n is only used for the fixed rule
+func SalvoRuleFromName(name string, n int) (SalvoRule, error) {
+	switch name {
+	case SalvoRuleSingleShotName:
+		return &SingleShotSalvoRule{}, nil
+	case SalvoRuleShipsAliveName:
+		return &ShipsAliveSalvoRule{}, nil
+	case SalvoRuleCellsAliveName:
+		return &CellsAliveSalvoRule{}, nil
+	case SalvoRuleFixedName:
+		if n < 1 {
+			return nil, errors.New("Invalid salvo rule: fixed should have at least 1 shot")
+		}
+
+		return &FixedSalvoRule{N: n}, nil
+	default:
+		return nil, errors.Errorf("Invalid salvo rule: %s", name)
+	}
+}
@@ -0,0 +1,65 @@
+package ssgame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSalvoRuleFromName(t *testing.T) {
+	assert := require.New(t)
+
+	for _, name := range []string{SalvoRuleSingleShotName, SalvoRuleShipsAliveName, SalvoRuleCellsAliveName} {
+		rule, err := SalvoRuleFromName(name, 0)
+		assert.NoError(err)
+		assert.Equal(name, rule.Name())
+	}
+
+	rule, err := SalvoRuleFromName(SalvoRuleFixedName, 3)
+	assert.NoError(err)
+	assert.Equal(&FixedSalvoRule{N: 3}, rule)
+
+	_, err = SalvoRuleFromName(SalvoRuleFixedName, 0)
+	assert.Error(err)
+	_, err = SalvoRuleFromName("all-you-can-shoot", 0)
+	assert.Error(err)
+}
+
+func TestSalvoRule_Shots(t *testing.T) {
+	assert := require.New(t)
+
+	rules := &Rules{
+		Width:  8,
+		Height: 8,
+		Fleet: Fleet{
+			{Name: "Line", Pattern: []string{"***"}, Count: 2},
+		},
+		Salvo: &ShipsAliveSalvoRule{},
+	}
+
+	selfBoard, err := NewSelfBoardFromPlacements(rules, []*Placement{
+		{Name: "Line", Offset: mustCoordsFromString("0x0")},
+		{Name: "Line", Offset: mustCoordsFromString("0x2")},
+	})
+	assert.NoError(err)
+	opponentBoard, err := NewBlankOpponentBoard(rules, 2)
+	assert.NoError(err)
+
+	// kill 1 spaceship and hit the other
+	res := selfBoard.ReceiveSalvo(CoordsGroup{
+		mustCoordsFromString("0x0"),
+		mustCoordsFromString("1x0"),
+		mustCoordsFromString("2x0"),
+		mustCoordsFromString("0x2"),
+	})
+	for _, shotResult := range res {
+		opponentBoard.ApplyShotStatus(shotResult.Coords, shotResult.ShotStatus)
+	}
+
+	for _, fleet := range []FleetStatus{selfBoard, opponentBoard} {
+		assert.Equal(1, (&SingleShotSalvoRule{}).Shots(fleet))
+		assert.Equal(1, (&ShipsAliveSalvoRule{}).Shots(fleet))
+		assert.Equal(2, (&CellsAliveSalvoRule{}).Shots(fleet))
+		assert.Equal(4, (&FixedSalvoRule{N: 4}).Shots(fleet))
+	}
+}
@@ -7,23 +7,32 @@ import (
 	"github.com/pkg/errors"
 )
 
+// the max dimensions of a spaceship pattern, whether it actually fits is checked when it's placed on a board
+const SpaceshipMaxRows = DefaultHeight
+const SpaceshipMaxCols = DefaultWidth
+
+// the cells and hits of a spaceship are also kept as bitboards once it's placed on a board
+//  name is the name of the spaceship in the fleet, empty when it's not part of one
 type Spaceship struct {
-	coords CoordsGroup
-	hits   CoordsGroup
-	dead   bool
+	name     string
+	coords   CoordsGroup
+	hits     CoordsGroup
+	dead     bool
+	cells    Bitboard
+	hitCells Bitboard
 }
 
 // @TODO: should sanitize any padding
 // create a shapeship from a pattern
 func SpaceshipFromPattern(pattern []string) (*Spaceship, error) {
 	// sanity check the input
-	if len(pattern) > ROWS {
+	if len(pattern) > SpaceshipMaxRows {
 		return nil, errors.New("pattern too many rows")
 	}
 
 	// sanity check the input
 	for _, row := range pattern {
-		if len(row) > COLS {
+		if len(row) > SpaceshipMaxCols {
 			return nil, errors.New("pattern too many cols")
 		}
 
@@ -64,12 +73,30 @@ func SpaceshipFromPattern(pattern []string) (*Spaceship, error) {
 // make a copy of the spaceship instance (so we don't mutate the original)
 func (s *Spaceship) Copy() *Spaceship {
 	return &Spaceship{
-		coords: s.coords.Copy(),
-		hits:   s.hits.Copy(),
-		dead:   s.dead,
+		name:     s.name,
+		coords:   s.coords.Copy(),
+		hits:     s.hits.Copy(),
+		dead:     s.dead,
+		cells:    s.cells,
+		hitCells: s.hitCells,
 	}
 }
 
+// build the bitboards of the spaceship for where it is now, the spaceship should be within the bounds of the board
+func (s *Spaceship) place() {
+	s.cells, _ = BitboardFromCoords(s.coords)
+	s.hitCells, _ = BitboardFromCoords(s.hits)
+}
+
+func (s *Spaceship) Name() string {
+	return s.name
+}
+
+// the coords of the spaceship (a copy, so they can't be used to mutate the spaceship)
+func (s *Spaceship) Coords() CoordsGroup {
+	return s.coords.Copy()
+}
+
 func (s *Spaceship) offset(x int8, y int8) {
 	for _, coords := range s.coords {
 		coords.x += x
@@ -87,55 +114,80 @@ func (s *Spaceship) CopyWithOffset(x int8, y int8) *Spaceship {
 
 // rotate the spaceship 90, 180 or 270 degrees
 func (s *Spaceship) rotate(rotate uint16) error {
-	// rotate coords
-	switch rotate {
-	case 0:
-		// nothing to do
-	case 90:
-		for _, coords := range s.coords {
-			coords.x, coords.y = coords.y*-1, coords.x
-		}
-	case 180:
-		for _, coords := range s.coords {
-			coords.x, coords.y = coords.x, coords.y*-1
+	return s.orient(Orientation{Rotation: rotate})
+}
+
+// put the spaceship in an orientation, the spaceship stays in the same spot (the top left of it does)
+func (s *Spaceship) orient(orientation Orientation) error {
+	err := orientation.Validate()
+	if err != nil {
+		return err
+	}
+
+	// remember where the spaceship was so we can put it back there
+	var minX, minY int8
+	for i, coords := range s.coords {
+		if i == 0 || coords.x < minX {
+			minX = coords.x
 		}
-	case 270:
-		for _, coords := range s.coords {
-			coords.x, coords.y = coords.y, coords.x*-1
+		if i == 0 || coords.y < minY {
+			minY = coords.y
 		}
-
-	default:
-		return errors.Errorf("Unsupported rotate: %d", rotate)
 	}
 
-	// offset coords if they went out of bounds
-	var offsetX int8
-	var offsetY int8
+	// orient coords
 	for _, coords := range s.coords {
-		if 0-coords.x > offsetX {
-			offsetX = 0 - coords.x
+		coords.x, coords.y = orientation.transform(coords.x-minX, coords.y-minY)
+	}
+
+	// offset coords back to where they were
+	var offsetX, offsetY int8
+	for i, coords := range s.coords {
+		if i == 0 || coords.x < offsetX {
+			offsetX = coords.x
 		}
-		if 0-coords.y > offsetY {
-			offsetY = 0 - coords.y
+		if i == 0 || coords.y < offsetY {
+			offsetY = coords.y
 		}
 	}
 
 	for _, coords := range s.coords {
-		coords.x += offsetX
-		coords.y += offsetY
+		coords.x += minX - offsetX
+		coords.y += minY - offsetY
 	}
 
 	return nil
 }
 
-// make a copy of the spaceship instance and give it an offset (so we don't mutate the original)
+// make a copy of the spaceship instance and rotate it (so we don't mutate the original)
 func (s *Spaceship) CopyWithRotate(rotate uint16) *Spaceship {
+	return s.CopyWithOrientation(Orientation{Rotation: rotate})
+}
+
+// make a copy of the spaceship instance and put it in an orientation (so we don't mutate the original)
+func (s *Spaceship) CopyWithOrientation(orientation Orientation) *Spaceship {
 	newS := s.Copy()
-	newS.rotate(rotate)
+	newS.orient(orientation)
 
 	return newS
 }
 
+// the width and height of the area the spaceship covers, starting from 0x0
+func (s *Spaceship) size() (int, int) {
+	var maxX int8 = 0
+	var maxY int8 = 0
+	for _, coords := range s.coords {
+		if coords.x > maxX {
+			maxX = coords.x
+		}
+		if coords.y > maxY {
+			maxY = coords.y
+		}
+	}
+
+	return int(maxX) + 1, int(maxY) + 1
+}
+
 // turn the spaceship back into a pattern (currently only used for tests and debugging)
 func (s *Spaceship) ToPattern() []string {
 	var maxX int8 = 0
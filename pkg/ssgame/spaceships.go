@@ -1,7 +1,7 @@
 package ssgame
 
 // this file contains the spaceship patterns
-//  and the set of spaceships used for a game
+//  and the set of spaceships used for a standard game, see BaseGameFleet for the same set with names
 
 var SpaceshipPatternWinger = []string{
 	"*.*",